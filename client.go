@@ -0,0 +1,67 @@
+package astm
+
+import (
+	"context"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"net"
+)
+
+// Client is a connection to an instrument for pushing messages to it
+// Messages sent by the instrument on the same link are passed to the handler
+type Client struct {
+	session *Session
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func Dial(ctx context.Context, address string, handler Handler, configuration ...astmmodels.ClientConfiguration) (client *Client, err error) {
+	// Load configuration
+	config := astmmodels.DefaultClientConfiguration
+	if len(configuration) > 0 {
+		config = configuration[0]
+	}
+	// Connect to the instrument
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	// Serve the connection in the background until it is closed or lost
+	sessionCtx, cancel := context.WithCancel(context.Background())
+	client = &Client{
+		session: newSession(conn, config.Configuration, config.Transmission, config.MessageQueueSize),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(client.done)
+		client.session.serve(sessionCtx, conn, handler)
+	}()
+	return client, nil
+}
+
+func (c *Client) Send(ctx context.Context, sourceStruct interface{}) (err error) {
+	// Marshal and transmit the message, the result is its delivery status
+	return c.session.Reply(ctx, sourceStruct)
+}
+
+func (c *Client) SendLines(ctx context.Context, lines [][]byte) (err error) {
+	// Transmit already marshalled lines, the result is their delivery status
+	return c.session.Send(ctx, lines)
+}
+
+func (c *Client) Session() *Session {
+	return c.session
+}
+
+func (c *Client) Done() <-chan struct{} {
+	// Closed when the connection is lost or the client is closed
+	return c.done
+}
+
+func (c *Client) Close() (err error) {
+	// Note: must not be called from the handler, as it waits for the handler to return
+	c.cancel()
+	<-c.done
+	return nil
+}
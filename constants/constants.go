@@ -3,9 +3,34 @@ package constants
 // Maximum recurtion depth for struct parsing and building
 const MaxDepth int = 42
 
+// Deepest substructure level (the substructure of a field contains components, the substructure of a component sub-components)
+const MaxSubstructureDepth int = 1
+
 // Attributes for annotations
 const AttributeRequired string = "required" // field-annotation: by default all fields are optinal
 const AttributeOptional string = "optional" // record-annotation: by default all records are mandatory
 const AttributeLongdate string = "longdate" // Indicating that the date should be formatted as date and time (output only)
 const AttributeLength string = "length"     // used for specifying the decimal length of float fields - astm:"1,length:2" (output only)
 const AttributeSubname string = "subname"   // used for specifying a subname for a record - astm:"M,subname:MATRIX"
+const AttributeLayout string = "layout"     // used for specifying an explicit Go time layout - astm:"5,layout:02.01.2006 15:04"
+const AttributeDefault string = "default"   // used for specifying the value of empty input and zero output fields - astm:"12,default:P"
+const AttributeMaxLength string = "maxlen"  // used for specifying the maximum length of a value - astm:"6,maxlen:20"
+const AttributeEnum string = "enum"         // used for specifying the allowed values separated by | - astm:"9,enum:M|F|U"
+
+// Control characters of the LIS01-A2 low-level protocol
+const STX byte = 0x02 // Start of text, opens a frame
+const ETX byte = 0x03 // End of text, closes the last frame of a record
+const EOT byte = 0x04 // End of transmission
+const ENQ byte = 0x05 // Enquiry, requests the line for establishment
+const ACK byte = 0x06 // Positive acknowledgement
+const NAK byte = 0x15 // Negative acknowledgement
+const ETB byte = 0x17 // End of transmission block, closes an intermediate frame
+const CR byte = 0x0D  // Carriage return, ends a record and the frame trailer
+const LF byte = 0x0A  // Line feed, ends the frame trailer
+
+// Framing limits of the LIS01-A2 low-level protocol
+const MaxFrameTextLength int = 240 // Maximum number of text characters in a single frame
+const FrameNumberModulo int = 8    // Frame numbers are counted modulo 8 starting with 1
+
+// Maximum length of a single line when reading from a stream
+const MaxStreamLineLength int = 1024 * 1024
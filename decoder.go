@@ -0,0 +1,91 @@
+package astm
+
+import (
+	"bufio"
+	"github.com/blutspende/bloodlab-common/encoding"
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/functions"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"io"
+	"strings"
+)
+
+// Decoder reads ASTM messages one after the other from a stream
+type Decoder struct {
+	scanner     *bufio.Scanner
+	config      *astmmodels.Configuration
+	pendingLine string
+	err         error
+}
+
+func NewDecoder(reader io.Reader, configuration ...astmmodels.Configuration) *Decoder {
+	decoder := &Decoder{}
+	// Load configuration (an error is returned by the first Decode)
+	decoder.config, decoder.err = loadConfiguration(configuration...)
+	if decoder.err != nil {
+		return decoder
+	}
+	// Set up the line scanner
+	decoder.scanner = bufio.NewScanner(reader)
+	decoder.scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), constants.MaxStreamLineLength)
+	decoder.scanner.Split(functions.ScanLines(decoder.config))
+	return decoder
+}
+
+func (d *Decoder) Decode(targetStruct interface{}) (err error) {
+	// Configuration errors are permanent
+	if d.err != nil {
+		return d.err
+	}
+	// Read the lines of the next message
+	lines, err := d.readMessage()
+	if err != nil {
+		return err
+	}
+	// Parse the lines into the target structure (delimiters are detected per message)
+	config := *d.config
+	lineIndex := 0
+	err = functions.ParseStruct(lines, targetStruct, &lineIndex, 1, 0, &config)
+	if err != nil {
+		return err
+	}
+	// Return nil if everything went well
+	return nil
+}
+
+func (d *Decoder) readMessage() (lines []string, err error) {
+	// A header read ahead by the previous call starts this message
+	if d.pendingLine != "" {
+		lines = append(lines, d.pendingLine)
+		d.pendingLine = ""
+	}
+	for d.scanner.Scan() {
+		// Convert encoding to UTF8 line by line
+		line, err := encoding.ConvertFromEncodingToUtf8(d.scanner.Bytes(), d.config.Encoding)
+		if err != nil {
+			return nil, err
+		}
+		line = strings.Trim(line, " ")
+		if line == "" {
+			continue
+		}
+		// A new header ends a message without terminator, it is kept for the next call
+		if line[0] == 'H' && len(lines) > 0 {
+			d.pendingLine = line
+			return lines, nil
+		}
+		lines = append(lines, line)
+		// The terminator record ends the message
+		if line[0] == 'L' {
+			return lines, nil
+		}
+	}
+	if err = d.scanner.Err(); err != nil {
+		return nil, err
+	}
+	// End of the stream
+	if len(lines) == 0 {
+		return nil, io.EOF
+	}
+	return lines, nil
+}
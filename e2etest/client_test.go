@@ -0,0 +1,212 @@
+package e2e
+
+import (
+	"context"
+	"github.com/blutspende/bloodlab-common/messagetype"
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/role"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/blutspende/go-astm/v3/transmission"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+// Start an instrument listening on a random local port, the accepted connection is returned on the channel
+func helperStartInstrument(t *testing.T, ctx context.Context) (string, chan *transmission.Connection) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	accepted := make(chan *transmission.Connection, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		transmissionConfig := astmmodels.DefaultTransmissionConfiguration
+		transmissionConfig.Role = role.Instrument
+		connection := transmission.NewConnection(conn, transmissionConfig)
+		go func() {
+			connection.Run(ctx)
+			conn.Close()
+		}()
+		accepted <- connection
+	}()
+	return listener.Addr().String(), accepted
+}
+
+func TestClientPushesOrder(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	address, accepted := helperStartInstrument(t, ctx)
+	clientConfig := astmmodels.DefaultClientConfiguration
+	clientConfig.Configuration = config
+	client, err := astm.Dial(ctx, address, nil, clientConfig)
+	assert.Nil(t, err)
+	defer client.Close()
+	instrument := <-accepted
+	var order lis02a2.OrderMessage
+	order.PatientOrders = []lis02a2.PatientOrder{{Orders: []lis02a2.Order{{SpecimenID: "SPECIMEN1"}}}}
+	order.Terminator.TerminatorCode = "N"
+	// Act
+	err = client.Send(ctx, order)
+	// Assert
+	assert.Nil(t, err)
+	messageData, err := instrument.Receive(ctx)
+	assert.Nil(t, err)
+	var received lis02a2.OrderMessage
+	err = astm.Unmarshal(messageData, &received, config)
+	assert.Nil(t, err)
+	assert.Equal(t, "SPECIMEN1", received.PatientOrders[0].Orders[0].SpecimenID)
+}
+
+func TestClientHandlesInstrumentQuery(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	address, accepted := helperStartInstrument(t, ctx)
+	clientConfig := astmmodels.DefaultClientConfiguration
+	clientConfig.Configuration = config
+	handler := func(ctx context.Context, session *astm.Session, messageData []byte, messageType messagetype.MessageType) {
+		assert.Equal(t, messagetype.Query, messageType)
+		var order lis02a2.OrderMessage
+		order.PatientOrders = []lis02a2.PatientOrder{{Orders: []lis02a2.Order{{SpecimenID: "QUERIED"}}}}
+		order.Terminator.TerminatorCode = "N"
+		assert.Nil(t, session.Reply(ctx, order))
+	}
+	client, err := astm.Dial(ctx, address, handler, clientConfig)
+	assert.Nil(t, err)
+	defer client.Close()
+	instrument := <-accepted
+	var query lis02a2.QueryMessage
+	query.Queries = []lis02a2.Query{{StartingRangeIDNumber: "QUERIED"}}
+	query.Terminator.TerminatorCode = "N"
+	lines, _ := astm.Marshal(query, config)
+	// Act
+	err = instrument.Send(ctx, lines)
+	assert.Nil(t, err)
+	messageData, err := instrument.Receive(ctx)
+	// Assert
+	assert.Nil(t, err)
+	var received lis02a2.OrderMessage
+	err = astm.Unmarshal(messageData, &received, config)
+	assert.Nil(t, err)
+	assert.Equal(t, "QUERIED", received.PatientOrders[0].Orders[0].SpecimenID)
+}
+
+func TestClientRepliesWhileInstrumentSends(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	address, accepted := helperStartInstrument(t, ctx)
+	clientConfig := astmmodels.DefaultClientConfiguration
+	clientConfig.Configuration = config
+	secondSent := make(chan struct{})
+	replied := make(chan error, 1)
+	received := make(chan string, 2)
+	handler := func(ctx context.Context, session *astm.Session, messageData []byte, messageType messagetype.MessageType) {
+		var query lis02a2.QueryMessage
+		_ = astm.Unmarshal(messageData, &query, session.Configuration())
+		received <- query.Queries[0].StartingRangeIDNumber
+		if query.Queries[0].StartingRangeIDNumber != "QUERIED1" {
+			return
+		}
+		// Reply only after the instrument transmitted its next message
+		<-secondSent
+		replyCtx, replyCancel := context.WithTimeout(ctx, 2*time.Second)
+		defer replyCancel()
+		var order lis02a2.OrderMessage
+		order.PatientOrders = []lis02a2.PatientOrder{{Orders: []lis02a2.Order{{SpecimenID: "QUERIED1"}}}}
+		replied <- session.Reply(replyCtx, order)
+	}
+	client, err := astm.Dial(ctx, address, handler, clientConfig)
+	assert.Nil(t, err)
+	defer client.Close()
+	instrument := <-accepted
+	var query lis02a2.QueryMessage
+	query.Queries = []lis02a2.Query{{StartingRangeIDNumber: "QUERIED1"}}
+	first, _ := astm.Marshal(query, config)
+	query.Queries[0].StartingRangeIDNumber = "QUERIED2"
+	second, _ := astm.Marshal(query, config)
+	// Act
+	assert.Nil(t, instrument.Send(ctx, first))
+	assert.Nil(t, instrument.Send(ctx, second))
+	close(secondSent)
+	messageData, err := instrument.Receive(ctx)
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, <-replied)
+	var order lis02a2.OrderMessage
+	err = astm.Unmarshal(messageData, &order, config)
+	assert.Nil(t, err)
+	assert.Equal(t, "QUERIED1", order.PatientOrders[0].Orders[0].SpecimenID)
+	assert.Equal(t, "QUERIED1", <-received)
+	assert.Equal(t, "QUERIED2", <-received)
+}
+
+func TestClientDeliveryFailure(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	go func() {
+		// The instrument is always busy
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buffer := make([]byte, 1)
+		for {
+			if _, err := conn.Read(buffer); err != nil {
+				return
+			}
+			if buffer[0] == 0x05 {
+				conn.Write([]byte{0x15})
+			}
+		}
+	}()
+	clientConfig := astmmodels.DefaultClientConfiguration
+	clientConfig.Configuration = config
+	clientConfig.Transmission.BusyRetryDelay = 10 * time.Millisecond
+	clientConfig.Transmission.MaxRetransmissions = 2
+	client, err := astm.Dial(ctx, listener.Addr().String(), nil, clientConfig)
+	assert.Nil(t, err)
+	defer client.Close()
+	// Act
+	err = client.SendLines(ctx, [][]byte{[]byte("H|\\^&"), []byte("L|1|N")})
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrTransmissionReceiverBusy)
+}
+
+func TestClientConnectionLost(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+	// Act
+	client, err := astm.Dial(ctx, listener.Addr().String(), nil)
+	assert.Nil(t, err)
+	// Assert
+	select {
+	case <-client.Done():
+	case <-ctx.Done():
+		t.Fatal("connection loss not detected")
+	}
+	err = client.SendLines(ctx, [][]byte{[]byte("H|\\^&")})
+	assert.ErrorIs(t, err, errmsg.ErrTransmissionConnectionClosed)
+}
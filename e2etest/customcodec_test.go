@@ -0,0 +1,64 @@
+package e2e
+
+import (
+	"errors"
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// Quantity is a value with a unit, transmitted as value^unit
+type Quantity struct {
+	Value string
+	Unit  string
+}
+
+func (q Quantity) MarshalASTM(attributes map[string]string, config *astmmodels.Configuration) (string, error) {
+	return q.Value + config.Delimiters.Component + q.Unit, nil
+}
+func (q *Quantity) UnmarshalASTM(value string, attributes map[string]string, config *astmmodels.Configuration) error {
+	parts := strings.Split(value, config.Delimiters.Component)
+	if len(parts) != 2 {
+		return errors.New("quantity must have a value and a unit")
+	}
+	q.Value, q.Unit = parts[0], parts[1]
+	return nil
+}
+
+type QuantityRecord struct {
+	Quantity Quantity `astm:"4"`
+}
+type QuantityMessage struct {
+	Header     lis02a2.Header     `astm:"H"`
+	Result     QuantityRecord     `astm:"R"`
+	Terminator lis02a2.Terminator `astm:"L"`
+}
+
+func TestCustomCodecRoundTrip(t *testing.T) {
+	// Arrange
+	messageString := "H|\\^&\nR|1||12.5^mg/dl\nL|1|N\n"
+	var message QuantityMessage
+	// Act
+	err := astm.Unmarshal([]byte(messageString), &message, config)
+	assert.Nil(t, err)
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, Quantity{Value: "12.5", Unit: "mg/dl"}, message.Result.Quantity)
+	assert.Equal(t, "R|1||12.5^mg/dl", string(result[1]))
+}
+
+func TestCustomCodecError(t *testing.T) {
+	// Arrange
+	messageString := "H|\\^&\nR|1||12.5\nL|1|N\n"
+	var message QuantityMessage
+	// Act
+	err := astm.Unmarshal([]byte(messageString), &message, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	assert.ErrorContains(t, err, "quantity must have a value and a unit")
+}
@@ -0,0 +1,45 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/precision"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type AuditedResultRecord struct {
+	Value     string        `astm:"4"`
+	Started   astm.DateTime `astm:"12"`
+	Completed astm.DateTime `astm:"13"`
+}
+type AuditedResultMessage struct {
+	Header     lis02a2.Header        `astm:"H"`
+	Results    []AuditedResultRecord `astm:"R"`
+	Terminator lis02a2.Terminator    `astm:"L"`
+}
+
+func TestDateTimeRoundTrip(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&||||||||||||20240315123000",
+		"R|1||1.5||||||||202403151215|20240315122030+0100",
+		"R|2||2.5||||||||20240315|",
+		"L|1|N",
+	}
+	var message AuditedResultMessage
+	// Act
+	err := astm.Unmarshal([]byte(strings.Join(lines, "\n")), &message, config)
+	assert.Nil(t, err)
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, precision.Minute, message.Results[0].Started.Precision)
+	assert.Equal(t, precision.Second, message.Results[0].Completed.Precision)
+	assert.True(t, message.Results[0].Completed.HasOffset)
+	assert.Equal(t, precision.Day, message.Results[1].Started.Precision)
+	assert.True(t, message.Results[1].Completed.Time.IsZero())
+	assert.Equal(t, lines[1], string(result[1]))
+	assert.Equal(t, lines[2], string(result[2]))
+}
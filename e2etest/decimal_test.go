@@ -0,0 +1,55 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type DecimalResultRecord struct {
+	Value astm.Decimal `astm:"4"`
+	Units string       `astm:"5"`
+}
+type DecimalResultMessage struct {
+	Header     lis02a2.Header        `astm:"H"`
+	Results    []DecimalResultRecord `astm:"R"`
+	Terminator lis02a2.Terminator    `astm:"L"`
+}
+
+func TestDecimalRoundTrip(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&||||||||||||20240315123000",
+		"R|1||1.50|mmol/l",
+		"R|2||0.0010|g/l",
+		"R|3|||",
+		"L|1|N",
+	}
+	var message DecimalResultMessage
+	// Act
+	err := astm.Unmarshal([]byte(strings.Join(lines, "\n")), &message, config)
+	assert.Nil(t, err)
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 2, message.Results[0].Value.Scale())
+	assert.Equal(t, 0.001, message.Results[1].Value.Float64())
+	assert.True(t, message.Results[2].Value.IsEmpty())
+	assert.Equal(t, lines[1], string(result[1]))
+	assert.Equal(t, lines[2], string(result[2]))
+}
+func TestDecimalRounded(t *testing.T) {
+	// Arrange
+	value, err := astm.ParseDecimal("2.345")
+	assert.Nil(t, err)
+	message := DecimalResultMessage{
+		Results: []DecimalResultRecord{{Value: value.Round(2), Units: "mg/dl"}},
+	}
+	// Act
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "R|1||2.35|mg/dl", string(result[1]))
+}
@@ -0,0 +1,85 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecoderMultipleMessages(t *testing.T) {
+	// Arrange
+	stream := "H|\\^&|||First|||||||||20220315194227\r"
+	stream += "Q|1|SPECIMEN1\r"
+	stream += "L|1|N\r"
+	stream += "H|\\^&|||Second|||||||||20220315194227\r"
+	stream += "Q|1|SPECIMEN2\r"
+	stream += "Q|2|SPECIMEN3\r"
+	stream += "L|1|N\r"
+	decoder := astm.NewDecoder(strings.NewReader(stream), config)
+	var first, second, third lis02a2.QueryMessage
+	// Act
+	errFirst := decoder.Decode(&first)
+	errSecond := decoder.Decode(&second)
+	errThird := decoder.Decode(&third)
+	// Assert
+	assert.Nil(t, errFirst)
+	assert.Equal(t, "First", first.Header.SenderNameOrID)
+	assert.Len(t, first.Queries, 1)
+	assert.Equal(t, "SPECIMEN1", first.Queries[0].StartingRangeIDNumber)
+	assert.Nil(t, errSecond)
+	assert.Equal(t, "Second", second.Header.SenderNameOrID)
+	assert.Len(t, second.Queries, 2)
+	assert.Equal(t, "SPECIMEN3", second.Queries[1].StartingRangeIDNumber)
+	assert.ErrorIs(t, errThird, io.EOF)
+}
+
+type DecoderNoTerminatorMessage struct {
+	Header  lis02a2.Header  `astm:"H"`
+	Queries []lis02a2.Query `astm:"Q"`
+}
+
+func TestDecoderMessageWithoutTerminator(t *testing.T) {
+	// Arrange
+	stream := "H|\\^&|||First\nQ|1|SPECIMEN1\nH|\\^&|||Second\nQ|1|SPECIMEN2\n"
+	decoder := astm.NewDecoder(strings.NewReader(stream), config)
+	var first, second DecoderNoTerminatorMessage
+	// Act
+	errFirst := decoder.Decode(&first)
+	errSecond := decoder.Decode(&second)
+	errEnd := decoder.Decode(&DecoderNoTerminatorMessage{})
+	// Assert
+	assert.Nil(t, errFirst)
+	assert.Equal(t, "SPECIMEN1", first.Queries[0].StartingRangeIDNumber)
+	assert.Nil(t, errSecond)
+	assert.Equal(t, "Second", second.Header.SenderNameOrID)
+	assert.Equal(t, "SPECIMEN2", second.Queries[0].StartingRangeIDNumber)
+	assert.ErrorIs(t, errEnd, io.EOF)
+}
+
+func TestDecoderContinuesAfterInvalidMessage(t *testing.T) {
+	// Arrange
+	stream := "H|\\^&\rX|1\rL|1|N\rH|\\^&|||Valid\rQ|1|SPECIMEN1\rL|1|N\r"
+	decoder := astm.NewDecoder(strings.NewReader(stream), config)
+	var invalid, valid lis02a2.QueryMessage
+	// Act
+	errInvalid := decoder.Decode(&invalid)
+	errValid := decoder.Decode(&valid)
+	// Assert
+	assert.ErrorIs(t, errInvalid, errmsg.ErrStructureParsingLineTypeNameMismatch)
+	assert.Nil(t, errValid)
+	assert.Equal(t, "Valid", valid.Header.SenderNameOrID)
+}
+
+func TestDecoderEmptyStream(t *testing.T) {
+	// Arrange
+	decoder := astm.NewDecoder(strings.NewReader("\r\n"), config)
+	var message lis02a2.QueryMessage
+	// Act
+	err := decoder.Decode(&message)
+	// Assert
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,48 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type TemplateHeader struct {
+	SenderName   string `astm:"5"`
+	ProcessingID string `astm:"12,default:P"`
+	Version      string `astm:"13,default:LIS2-A2"`
+}
+type TemplateOrder struct {
+	SpecimenID string `astm:"3"`
+	ActionCode string `astm:"12,default:N"`
+}
+type TemplateMessage struct {
+	Header TemplateHeader  `astm:"H"`
+	Orders []TemplateOrder `astm:"O"`
+}
+
+func TestDefaultValuesMarshal(t *testing.T) {
+	// Arrange
+	message := TemplateMessage{
+		Header: TemplateHeader{SenderName: "LIS"},
+		Orders: []TemplateOrder{{SpecimenID: "SID1"}, {SpecimenID: "SID2", ActionCode: "A"}},
+	}
+	// Act
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "H|\\^&|||LIS|||||||P|LIS2-A2", string(result[0]))
+	assert.Equal(t, "O|1|SID1|||||||||N", string(result[1]))
+	assert.Equal(t, "O|2|SID2|||||||||A", string(result[2]))
+}
+func TestDefaultValuesUnmarshal(t *testing.T) {
+	// Arrange
+	data := "H|\\^&|||LIS\nO|1|SID1"
+	var message TemplateMessage
+	// Act
+	err := astm.Unmarshal([]byte(data), &message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P", message.Header.ProcessingID)
+	assert.Equal(t, "LIS2-A2", message.Header.Version)
+	assert.Equal(t, "N", message.Orders[0].ActionCode)
+}
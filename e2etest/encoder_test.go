@@ -0,0 +1,132 @@
+package e2e
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/lineseparator"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func helperQueryMessage(specimenID string) lis02a2.QueryMessage {
+	var message lis02a2.QueryMessage
+	message.Header.SenderNameOrID = "LIS"
+	message.Queries = []lis02a2.Query{{StartingRangeIDNumber: specimenID}}
+	message.Terminator.TerminatorCode = "N"
+	return message
+}
+
+func TestEncoderDefaultRecordTerminator(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	encoder := astm.NewEncoder(&output)
+	// Act
+	err := encoder.Encode(helperQueryMessage("SPECIMEN1"))
+	// Assert
+	assert.Nil(t, err)
+	expected := "H|\\^&|||LIS|||||||||\r"
+	expected += "Q|1|SPECIMEN1||||||||||\r"
+	expected += "L|1|N\r"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestEncoderDefaultConfigurationRecordTerminator(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	encoder := astm.NewEncoder(&output, astmmodels.DefaultConfiguration)
+	// Act
+	err := encoder.Encode(helperQueryMessage("SPECIMEN1"))
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "H|\\^&|||LIS|||||||||\rQ|1|SPECIMEN1||||||||||\rL|1|N\r", output.String())
+}
+
+func TestEncoderConfiguredRecordTerminator(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	config.LineSeparator = lineseparator.LF
+	config.AutoDetectLineSeparator = false
+	encoder := astm.NewEncoder(&output, config)
+	// Act
+	err := encoder.Encode(helperQueryMessage("SPECIMEN1"))
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "H|\\^&|||LIS|||||||||\nQ|1|SPECIMEN1||||||||||\nL|1|N\n", output.String())
+	teardown()
+}
+
+func TestEncoderEmptyConfiguredRecordTerminator(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	config.LineSeparator = ""
+	encoder := astm.NewEncoder(&output, config)
+	// Act
+	err := encoder.Encode(helperQueryMessage("SPECIMEN1"))
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "H|\\^&|||LIS|||||||||\rQ|1|SPECIMEN1||||||||||\rL|1|N\r", output.String())
+	teardown()
+}
+
+func TestEncoderCustomRecordTerminator(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	encoder := astm.NewEncoder(&output, config)
+	encoder.SetRecordTerminator(lineseparator.CRLF)
+	// Act
+	err := encoder.Encode(helperQueryMessage("SPECIMEN1"))
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "H|\\^&|||LIS|||||||||\r\nQ|1|SPECIMEN1||||||||||\r\nL|1|N\r\n", output.String())
+}
+
+func TestEncoderDecoderRoundTrip(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	encoder := astm.NewEncoder(&output, config)
+	// Act
+	errFirst := encoder.Encode(helperQueryMessage("SPECIMEN1"))
+	errSecond := encoder.Encode(helperQueryMessage("SPECIMEN2"))
+	decoder := astm.NewDecoder(&output, config)
+	var first, second lis02a2.QueryMessage
+	errDecodeFirst := decoder.Decode(&first)
+	errDecodeSecond := decoder.Decode(&second)
+	// Assert
+	assert.Nil(t, errFirst)
+	assert.Nil(t, errSecond)
+	assert.Nil(t, errDecodeFirst)
+	assert.Nil(t, errDecodeSecond)
+	assert.Equal(t, "SPECIMEN1", first.Queries[0].StartingRangeIDNumber)
+	assert.Equal(t, "SPECIMEN2", second.Queries[0].StartingRangeIDNumber)
+}
+
+func TestEncoderFramed(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	encoder := astm.NewEncoder(&output, config)
+	encoder.SetFraming(true)
+	// Act
+	err := encoder.Encode(helperQueryMessage("SPECIMEN1"))
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, byte(0x02), output.Bytes()[0])
+	messageData, err := astm.Deframe(output.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, "H|\\^&|||LIS|||||||||\rQ|1|SPECIMEN1||||||||||\rL|1|N\r", string(messageData))
+}
+
+func TestEncoderFlushesPerMessage(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	writer := bufio.NewWriterSize(&output, 4096)
+	encoder := astm.NewEncoder(writer, config)
+	// Act
+	err := encoder.Encode(helperQueryMessage("SPECIMEN1"))
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 0, writer.Buffered())
+	assert.Contains(t, output.String(), "SPECIMEN1")
+}
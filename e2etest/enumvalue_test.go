@@ -0,0 +1,45 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type ValidatedPatientRecord struct {
+	Gender string `astm:"9,enum:M|F|U"`
+}
+type ValidatedPatientMessage struct {
+	Header   struct{}                 `astm:"H"`
+	Patients []ValidatedPatientRecord `astm:"P"`
+}
+
+func TestEnumValueUnmarshal(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&",
+		"P|1|||||||F",
+		"P|2|||||||W",
+	}
+	var message ValidatedPatientMessage
+	// Act
+	err := astm.Unmarshal([]byte(strings.Join(lines, "\n")), &message, config)
+	// Assert
+	var parseError *astm.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingInvalidEnumValue)
+	assert.Equal(t, 3, parseError.Line)
+	assert.Equal(t, "W", parseError.Value)
+}
+func TestEnumValueMarshal(t *testing.T) {
+	// Arrange
+	message := ValidatedPatientMessage{
+		Patients: []ValidatedPatientRecord{{Gender: "M"}, {Gender: "male"}},
+	}
+	// Act
+	_, err := astm.Marshal(message, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineBuildingInvalidEnumValue)
+}
@@ -0,0 +1,47 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestFrameAndDeframeRoundTrip(t *testing.T) {
+	// Arrange
+	var message lis02a2.QueryMessage
+	message.Header.SenderNameOrID = "LIS"
+	message.Queries = []lis02a2.Query{{StartingRangeIDNumber: strings.Repeat("1", 300)}}
+	message.Terminator.TerminatorCode = "N"
+	lines, _ := astm.Marshal(message, config)
+	// Act
+	frames, err := astm.Frame(lines)
+	assert.Nil(t, err)
+	framedData := []byte{0x05}
+	for _, frame := range frames {
+		framedData = append(framedData, frame...)
+	}
+	framedData = append(framedData, 0x04)
+	messageData, err := astm.Deframe(framedData)
+	assert.Nil(t, err)
+	var result lis02a2.QueryMessage
+	err = astm.Unmarshal(messageData, &result, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, frames, 4)
+	assert.Equal(t, "LIS", result.Header.SenderNameOrID)
+	assert.Len(t, result.Queries, 1)
+	assert.Equal(t, strings.Repeat("1", 300), result.Queries[0].StartingRangeIDNumber)
+	assert.Equal(t, lis02a2.TerminatorCodeNormal, result.Terminator.TerminatorCode)
+}
+
+func TestDeframeChecksumMismatch(t *testing.T) {
+	// Arrange
+	framedData := []byte("\u00021H|\\^&\r\u000300\r\n")
+	// Act
+	_, err := astm.Deframe(framedData)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrFramingChecksumMismatch)
+}
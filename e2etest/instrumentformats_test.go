@@ -0,0 +1,208 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/operator"
+	"github.com/blutspende/go-astm/v3/models/messageformat/euroimmun"
+	"github.com/blutspende/go-astm/v3/models/messageformat/galileo"
+	"github.com/blutspende/go-astm/v3/models/messageformat/ihcom"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/blutspende/go-astm/v3/models/messageformat/yumizen"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Unmarshal an example capture, marshal it (it has to give the lines of the capture) and unmarshal the marshalled message again into roundTrip
+func helperExampleRoundTrip(t *testing.T, path string, message any, roundTrip any) {
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	err = astm.Unmarshal(data, message, config)
+	assert.Nil(t, err)
+	lines, err := astm.Marshal(message, config)
+	assert.Nil(t, err)
+	inputLines := strings.FieldsFunc(string(data), func(r rune) bool { return r == '\n' || r == '\r' })
+	for i := range inputLines {
+		inputLines[i] = helperNormalizeLine(inputLines[i])
+	}
+	marshalledLines := make([]string, len(lines))
+	marshalled := []byte{}
+	for i, line := range lines {
+		marshalledLines[i] = helperNormalizeLine(string(line))
+		marshalled = append(marshalled, line...)
+		marshalled = append(marshalled, []byte(config.LineSeparator)...)
+	}
+	assert.Equal(t, inputLines, marshalledLines)
+	err = astm.Unmarshal(marshalled, roundTrip, config)
+	assert.Nil(t, err)
+}
+
+// Remove what marshal does not preserve: trailing spaces, trailing empty fields, repeats and components and the sequence numbers
+func helperNormalizeLine(line string) string {
+	fields := strings.Split(strings.TrimRight(line, " "), config.Delimiters.Field)
+	for i := range fields {
+		// The delimiters of the header are not a field, the other records are numbered by marshal
+		if i == 1 {
+			if fields[0] != "H" {
+				fields[i] = "#"
+			}
+			continue
+		}
+		repeats := strings.Split(fields[i], config.Delimiters.Repeat)
+		for j := range repeats {
+			repeats[j] = helperJoinWithoutTrailingEmpty(strings.Split(repeats[j], config.Delimiters.Component), config.Delimiters.Component)
+		}
+		fields[i] = helperJoinWithoutTrailingEmpty(repeats, config.Delimiters.Repeat)
+	}
+	return helperJoinWithoutTrailingEmpty(fields, config.Delimiters.Field)
+}
+
+func helperJoinWithoutTrailingEmpty(values []string, delimiter string) string {
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	return strings.Join(values, delimiter)
+}
+
+func TestEuroimmunAnalyzer1ResultExample(t *testing.T) {
+	// Arrange
+	var message, roundTrip euroimmun.ResultMessage
+	// Act
+	helperExampleRoundTrip(t, "../examples/euroimmun_analyzer1_v10/sampleigg.astm", &message, &roundTrip)
+	// Assert
+	assert.Len(t, message.PatientResults, 20)
+	assert.Equal(t, "TEST-27-079-5-1", message.PatientResults[0].Patient.LabAssignedPatientID)
+	order := message.PatientResults[0].OrderResults[0]
+	assert.Equal(t, "SARSCOV2IGA", order.Order.UniversalTestID.ManufacturersTestType)
+	assert.Equal(t, time.Date(2022, 2, 18, 8, 7, 37, 0, config.TimeLocation).UTC(), order.Order.RequestedOrderDateTime)
+	assert.Equal(t, operator.GreaterThan, order.Results[0].Value.Operator)
+	assert.Equal(t, "8", order.Results[0].Value.Number.String())
+	assert.Equal(t, "7.41", message.PatientResults[1].OrderResults[0].Results[0].Value.Number.String())
+	assert.Equal(t, "Ratio", order.Results[0].Units)
+	assert.Equal(t, message, roundTrip)
+	teardown()
+}
+
+func TestGalileoOrderExample(t *testing.T) {
+	// Arrange
+	var message, roundTrip galileo.OrderMessage
+	// Act
+	helperExampleRoundTrip(t, "../examples/galileo/order.astm", &message, &roundTrip)
+	// Assert
+	assert.Equal(t, "Echo", message.Header.ReceiverID)
+	patient := message.PatientOrders[0].Patient
+	assert.Equal(t, "1171984", patient.PracticeAssignedPatientID)
+	assert.Equal(t, "Patient", patient.LastName)
+	orderGroup := message.PatientOrders[0].OrderGroups[0]
+	assert.Equal(t, "Crossmatch", orderGroup.Order.UniversalTestID.ManufacturersTestType)
+	assert.Equal(t, lis02a2.ActionCodeNew, orderGroup.Order.ActionCode)
+	assert.Equal(t, "Blood", orderGroup.Order.SpecimenType)
+	assert.Len(t, orderGroup.DonorComments, 1)
+	assert.Equal(t, "R02460", orderGroup.DonorComments[0].DonorUnitNumber)
+	assert.Equal(t, message, roundTrip)
+	teardown()
+}
+
+func TestGalileoResultExample(t *testing.T) {
+	// Arrange
+	var message, roundTrip galileo.ResultMessage
+	// Act
+	helperExampleRoundTrip(t, "../examples/galileo/result.astm", &message, &roundTrip)
+	// Assert
+	orderResults := message.PatientResults[0].OrderResults
+	assert.Len(t, orderResults, 2)
+	assert.Len(t, orderResults[0].Results, 9)
+	assert.Len(t, orderResults[1].Results, 5)
+	result := orderResults[0].Results[2]
+	assert.Equal(t, "Anti-B", result.UniversalTestID.ManufacturersTestType)
+	assert.Equal(t, "3+", result.DataMeasurementValue)
+	assert.Equal(t, "45", result.MeasurementValueOfDevice)
+	assert.Equal(t, lis02a2.ResultStatusFinal, result.ResultStatus)
+	assert.Equal(t, "brentp", result.OperatorIDPerformed)
+	assert.Equal(t, "M0002", result.InstrumentIdentification)
+	assert.Equal(t, "B Pos", orderResults[0].Results[8].DataMeasurementValue)
+	assert.Equal(t, message, roundTrip)
+	teardown()
+}
+
+func TestIHComBloodtypeExample(t *testing.T) {
+	// Arrange
+	var message, roundTrip ihcom.ResultMessage
+	// Act
+	helperExampleRoundTrip(t, "../examples/ihcom_v52/bloodtype.astm", &message, &roundTrip)
+	// Assert
+	assert.Equal(t, "Bio-Rad", message.Header.SenderNameOrID)
+	assert.Equal(t, "IH v5.2", message.Header.SoftwareVersion)
+	orderResult := message.PatientResults[0].OrderResults[0]
+	assert.Equal(t, []string{"1122206642", "1122206642"}, orderResult.Order.InstrumentSpecimenIDs)
+	assert.Equal(t, "MO10", orderResult.Order.UniversalTestID.ManufacturersTestType)
+	assert.Len(t, orderResult.ResultGroups, 4)
+	resultGroup := orderResult.ResultGroups[0]
+	assert.Equal(t, "AntiA", resultGroup.Result.UniversalTestID.ManufacturersTestType)
+	assert.Equal(t, "40^^", resultGroup.Result.Value)
+	assert.Equal(t, "IH-1000", resultGroup.Result.InstrumentName)
+	assert.Equal(t, "0300768", resultGroup.Result.InstrumentSerialNumber)
+	assert.Len(t, resultGroup.ReagentComments, 1)
+	reagentComment := resultGroup.ReagentComments[0]
+	assert.Equal(t, "ID-Diluent 2", reagentComment.Reagents[0].Name)
+	assert.Equal(t, "05761.03.12", reagentComment.Reagents[0].LotNumber)
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, config.TimeLocation), reagentComment.Reagents[0].ExpirationDate)
+	assert.Equal(t, "CAS", reagentComment.TypeOfTestMedia)
+	assert.Equal(t, "50053.52.06", reagentComment.LotNumberOfCassetteOrPlate)
+	assert.Equal(t, "A^NEG^NEG^ccee^K-^NEG^^^", orderResult.ResultGroups[3].Result.Value)
+	assert.Equal(t, message, roundTrip)
+	teardown()
+}
+
+func TestIHComBloodtypeMultiplePatientsExample(t *testing.T) {
+	// Arrange
+	var message, roundTrip ihcom.ResultMessage
+	// The patient records of the capture all have the sequence number 1
+	config.EnforceSequenceNumberCheck = false
+	// Act
+	helperExampleRoundTrip(t, "../examples/ihcom_v52/bloodtype_por.astm", &message, &roundTrip)
+	// Assert
+	assert.Len(t, message.PatientResults, 2)
+	assert.Equal(t, "Testis", message.PatientResults[1].Patient.LastName)
+	assert.Len(t, message.PatientResults[1].OrderResults[0].ResultGroups, 1)
+	assert.Equal(t, message, roundTrip)
+	teardown()
+}
+
+func TestYumizenResultExample(t *testing.T) {
+	// Arrange
+	var message, roundTrip yumizen.ResultMessage
+	// The manufacturer records are numbered across the histograms, matrices and traceability
+	config.EnforceSequenceNumberCheck = false
+	// The instrument doubles the repeat delimiter (H|\\^&)
+	config.Delimiters.Repeat = `\\`
+	// Act
+	helperExampleRoundTrip(t, "../examples/yumizen/result.astm", &message, &roundTrip)
+	// Assert
+	assert.Equal(t, "H550", message.Header.InstrumentModel)
+	assert.Equal(t, "909YAXH02732", message.Header.InstrumentSerialNumber)
+	assert.Equal(t, "1.2.1.4", message.Header.SoftwareVersion)
+	patientResult := message.PatientResults[0]
+	assert.Equal(t, "PX449L", patientResult.Order.SpecimenID)
+	assert.Equal(t, "CTRL", patientResult.Order.SpecimenType)
+	assert.Equal(t, "CTRLLOW", patientResult.Order.ControlLevel)
+	assert.Len(t, patientResult.Histograms, 1)
+	assert.Equal(t, "PltAlongRes", patientResult.Histograms[0].Name)
+	assert.Equal(t, "FLOATLE-stream/deflate:base64", patientResult.Histograms[0].Stream1Encoding)
+	assert.Len(t, patientResult.Matrices, 1)
+	assert.Equal(t, []string{"CLEANER", "DILUENT", "LYSE"}, patientResult.Traceability.ReagentNames)
+	assert.Len(t, patientResult.Traceability.Reagents, 3)
+	assert.Equal(t, "423H1(", patientResult.Traceability.Reagents[1].LotNumber)
+	assert.Equal(t, time.Date(2025, 3, 5, 0, 0, 0, 0, config.TimeLocation), patientResult.Traceability.Reagents[1].ExpirationDate)
+	assert.Len(t, patientResult.Results, 20)
+	assert.Equal(t, "MCV", patientResult.Results[0].TestCode)
+	assert.Equal(t, "787-2", patientResult.Results[0].LOINCCode)
+	assert.Equal(t, "78.4", patientResult.Results[0].Value.Number.String())
+	assert.Equal(t, "73.5-83.5", patientResult.Results[0].ReferenceRange)
+	assert.Equal(t, lis02a2.AbnormalFlagNormal, patientResult.Results[0].ResultAbnormalFlag)
+	assert.Equal(t, "USER", patientResult.Results[0].OperatorRole)
+	assert.Equal(t, message, roundTrip)
+	teardown()
+}
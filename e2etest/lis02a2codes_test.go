@@ -0,0 +1,70 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalTypedLIS02A2Codes(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&|||||||||||20240315123000",
+		"P|1",
+		"O|1|SPEC1||^^^SARSCOV2IGA|S||||||A||||||||||||||F",
+		"R|1|^^^SARSCOV2IGA|8.11|Ratio||HH|AS|F",
+		"L|1|N",
+	}
+	message := strings.Join(lines, string(config.LineSeparator)) + string(config.LineSeparator)
+	var result lis02a2.ResultMessage
+	// Act
+	err := astm.Unmarshal([]byte(message), &result, config)
+	// Assert
+	assert.Nil(t, err)
+	order := result.PatientGroups[0].OrderGroups[0].Order
+	assert.Equal(t, lis02a2.PriorityStat, order.Priority)
+	assert.Equal(t, lis02a2.ActionCodeAdd, order.ActionCode)
+	assert.Equal(t, lis02a2.ReportTypeFinal, order.ReportType)
+	resultRecord := result.PatientGroups[0].OrderGroups[0].ResultGroups[0].Result
+	assert.Equal(t, lis02a2.AbnormalFlagPanicHigh, resultRecord.ResultAbnormalFlag)
+	assert.Equal(t, "above panic high", resultRecord.ResultAbnormalFlag.Description())
+	assert.Equal(t, lis02a2.NatureOfAbnormalityTesting("AS"), resultRecord.NatureOfAbnormalTesting)
+	assert.Equal(t, "age based population tested, sex based population tested", resultRecord.NatureOfAbnormalTesting.Description())
+	assert.Equal(t, lis02a2.ResultStatusFinal, resultRecord.ResultStatus)
+	assert.Equal(t, lis02a2.TerminatorCodeNormal, result.Terminator.TerminatorCode)
+	teardown()
+}
+
+func TestMarshalTypedLIS02A2Codes(t *testing.T) {
+	// Arrange
+	message := lis02a2.QueryMessage{}
+	message.Queries = []lis02a2.Query{{StartingRangeIDNumber: "SPEC1", RequestInformationStatus: lis02a2.RequestInformationStatusOrdersOnly}}
+	message.Terminator.TerminatorCode = lis02a2.TerminatorCodeQueryProcessed
+	// Act
+	lines, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "Q|1|SPEC1||||||||||O", string(lines[1]))
+	assert.Equal(t, "L|1|F", string(lines[2]))
+	teardown()
+}
+
+func TestLIS02A2CodesValidity(t *testing.T) {
+	assert.True(t, lis02a2.PriorityRoutine.IsValid())
+	assert.False(t, lis02a2.Priority("Z").IsValid())
+	assert.True(t, lis02a2.ActionCodeQualityControl.IsValid())
+	assert.False(t, lis02a2.ActionCode("").IsValid())
+	assert.True(t, lis02a2.ReportTypeNoPatient.IsValid())
+	assert.True(t, lis02a2.AbnormalFlagBelowScale.IsValid())
+	assert.False(t, lis02a2.AbnormalFlag("LLL").IsValid())
+	assert.True(t, lis02a2.NatureOfAbnormalityTesting("ASR").IsValid())
+	assert.False(t, lis02a2.NatureOfAbnormalityTesting("AX").IsValid())
+	assert.False(t, lis02a2.NatureOfAbnormalityTesting("").IsValid())
+	assert.True(t, lis02a2.ResultStatusWarning.IsValid())
+	assert.True(t, lis02a2.RequestInformationStatusAbort.IsValid())
+	assert.False(t, lis02a2.TerminatorCode("X").IsValid())
+	assert.Equal(t, "no information available from last query", lis02a2.TerminatorCodeNoInformation.Description())
+	assert.Equal(t, "", lis02a2.TerminatorCode("X").Description())
+}
@@ -0,0 +1,99 @@
+package e2e
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/maxlengthpolicy"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+type LimitedPatientRecord struct {
+	LastName  string `astm:"6.1,maxlen:10"`
+	FirstName string `astm:"6.2,maxlen:5"`
+}
+type LimitedPatientMessage struct {
+	Header   struct{}               `astm:"H"`
+	Patients []LimitedPatientRecord `astm:"P"`
+}
+
+func TestMaxLengthTruncateWithWarning(t *testing.T) {
+	// Arrange
+	config.MaxLengthPolicy = maxlengthpolicy.TruncateWithWarning
+	message := LimitedPatientMessage{
+		Patients: []LimitedPatientRecord{
+			{LastName: "Doe", FirstName: "John"},
+			{LastName: "Wolfeschlegelsteinhausen", FirstName: "Hubert"},
+		},
+	}
+	// Act
+	result, report, err := astm.MarshalWithReport(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|1||||Doe^John", string(result[1]))
+	assert.Equal(t, "P|2||||Wolfeschle^Huber", string(result[2]))
+	assert.Equal(t, []astmmodels.TruncatedValue{
+		{RecordType: "P", SequenceNumber: 2, FieldPos: 6, MaxLength: 10, Value: "Wolfeschlegelsteinhausen"},
+		{RecordType: "P", SequenceNumber: 2, FieldPos: 6, MaxLength: 5, Value: "Hubert"},
+	}, report.TruncatedValues)
+	teardown()
+}
+func TestMaxLengthTruncateAtEscapeRoundTrip(t *testing.T) {
+	// Arrange
+	config.MaxLengthPolicy = maxlengthpolicy.Truncate
+	config.EscapeOutputStrings = true
+	message := LimitedPatientMessage{
+		Patients: []LimitedPatientRecord{{LastName: "Doe", FirstName: "Hube&rt"}},
+	}
+	var roundTrip LimitedPatientMessage
+	// Act
+	result, err := astm.Marshal(message, config)
+	assert.Nil(t, err)
+	err = astm.Unmarshal(bytes.Join(result, []byte("\n")), &roundTrip, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|1||||Doe^Hube&&", string(result[1]))
+	assert.Equal(t, "Hube&", roundTrip.Patients[0].FirstName)
+	teardown()
+}
+func TestMaxLengthTruncateWithWarningConcurrent(t *testing.T) {
+	// Arrange
+	config.MaxLengthPolicy = maxlengthpolicy.TruncateWithWarning
+	reports := make([]*astmmodels.MarshalReport, 8)
+	errs := make([]error, len(reports))
+	var wg sync.WaitGroup
+	// Act
+	for i := range reports {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			message := LimitedPatientMessage{
+				Patients: []LimitedPatientRecord{{LastName: fmt.Sprintf("Wolfeschlegel%d", i)}},
+			}
+			_, reports[i], errs[i] = astm.MarshalWithReport(message, config)
+		}(i)
+	}
+	wg.Wait()
+	// Assert
+	for i := range reports {
+		assert.Nil(t, errs[i])
+		assert.Equal(t, []astmmodels.TruncatedValue{
+			{RecordType: "P", SequenceNumber: 1, FieldPos: 6, MaxLength: 10, Value: fmt.Sprintf("Wolfeschlegel%d", i)},
+		}, reports[i].TruncatedValues)
+	}
+	teardown()
+}
+func TestMaxLengthErrorByDefault(t *testing.T) {
+	// Arrange
+	message := LimitedPatientMessage{
+		Patients: []LimitedPatientRecord{{LastName: "Wolfeschlegelsteinhausen"}},
+	}
+	// Act
+	_, err := astm.Marshal(message, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineBuildingMaxLengthExceeded)
+}
@@ -0,0 +1,60 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/operator"
+	"github.com/blutspende/go-astm/v3/enums/valuekind"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type QualifiedResultRecord struct {
+	UniversalTestID string                `astm:"3"`
+	Value           astm.MeasurementValue `astm:"4"`
+	Units           string                `astm:"5"`
+}
+type QualifiedResultMessage struct {
+	Header     lis02a2.Header          `astm:"H"`
+	Results    []QualifiedResultRecord `astm:"R"`
+	Terminator lis02a2.Terminator      `astm:"L"`
+}
+
+func TestMeasurementValueRoundTrip(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&||||||||||||20240315123000",
+		"R|1|SARSCOV2IGA|>8|Ratio",
+		"R|2|SARSCOV2IGA|8,11|Ratio",
+		"R|3|Screen 1|4+|",
+		"R|4|Anti-D|1:160|",
+		"R|5|Screen Interp|Pos|",
+		"L|1|N",
+	}
+	var message QualifiedResultMessage
+	// Act
+	err := astm.Unmarshal([]byte(strings.Join(lines, "\n")), &message, config)
+	assert.Nil(t, err)
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, operator.GreaterThan, message.Results[0].Value.Operator)
+	assert.Equal(t, 8.0, message.Results[0].Value.Number.Float64())
+	assert.Equal(t, 8.11, message.Results[1].Value.Number.Float64())
+	assert.Equal(t, valuekind.Grade, message.Results[2].Value.Kind)
+	assert.Equal(t, valuekind.Titer, message.Results[3].Value.Kind)
+	assert.Equal(t, "Pos", message.Results[4].Value.Text)
+	for i := 1; i <= 5; i++ {
+		assert.Equal(t, lines[i], string(result[i]))
+	}
+}
+func TestParseMeasurementValueOfStringField(t *testing.T) {
+	// Arrange
+	result := lis02a2.Result{DataMeasurementValue: "<0.05"}
+	// Act
+	value := astm.ParseMeasurementValue(result.DataMeasurementValue)
+	// Assert
+	assert.True(t, value.IsCensored())
+	assert.Equal(t, "0.05", value.Number.String())
+}
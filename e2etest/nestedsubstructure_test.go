@@ -0,0 +1,82 @@
+package e2e
+
+import (
+	"bytes"
+	"github.com/blutspende/go-astm/v3"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type SpecimenSource struct {
+	Code        string `astm:"1"`
+	Description string `astm:"2"`
+}
+type SpecimenDescriptor struct {
+	SpecimenType   string         `astm:"1"`
+	SpecimenSource SpecimenSource `astm:"2"`
+}
+type UniversalTestID struct {
+	Code      string   `astm:"4"`
+	Dilutions []string `astm:"5"`
+}
+type DescribedOrderRecord struct {
+	SpecimenID         string             `astm:"3"`
+	UniversalTestID    []UniversalTestID  `astm:"5"`
+	SpecimenDescriptor SpecimenDescriptor `astm:"16"`
+}
+type DescribedOrderMessage struct {
+	Header struct{}               `astm:"H"`
+	Orders []DescribedOrderRecord `astm:"O"`
+}
+
+func TestNestedSubstructureRoundTrip(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&",
+		"O|1|SID1||^^^GLU^1&2\\^^^NA^|||||||||||SERUM^VEN&Venous",
+	}
+	var message DescribedOrderMessage
+	// Act
+	err := astm.Unmarshal([]byte(strings.Join(lines, "\n")), &message, config)
+	assert.Nil(t, err)
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, message.Orders, 1)
+	assert.Equal(t, "SERUM", message.Orders[0].SpecimenDescriptor.SpecimenType)
+	assert.Equal(t, "Venous", message.Orders[0].SpecimenDescriptor.SpecimenSource.Description)
+	assert.Equal(t, []string{"1", "2"}, message.Orders[0].UniversalTestID[0].Dilutions)
+	assert.Equal(t, "NA", message.Orders[0].UniversalTestID[1].Code)
+	assert.Equal(t, lines[1], string(result[1]))
+}
+
+type DilutedTestID struct {
+	Dilutions []string `astm:"1"`
+	Code      string   `astm:"2"`
+}
+type DilutedResultRecord struct {
+	TestID DilutedTestID `astm:"3"`
+}
+type DilutedResultMessage struct {
+	Header  struct{}              `astm:"H"`
+	Results []DilutedResultRecord `astm:"R"`
+}
+
+func TestSubComponentTrailingEmptyRoundTrip(t *testing.T) {
+	// Arrange
+	message := DilutedResultMessage{
+		Results: []DilutedResultRecord{{TestID: DilutedTestID{Dilutions: []string{"a", "", "b", ""}, Code: "c"}}},
+	}
+	var roundTrip DilutedResultMessage
+	// Act
+	result, err := astm.Marshal(message, config)
+	assert.Nil(t, err)
+	err = astm.Unmarshal(bytes.Join(result, []byte("\n")), &roundTrip, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "R|1|a&&b^c", string(result[1]))
+	assert.Len(t, roundTrip.Results, 1)
+	assert.Equal(t, []string{"a", "", "b"}, roundTrip.Results[0].TestID.Dilutions)
+	assert.Equal(t, "c", roundTrip.Results[0].TestID.Code)
+}
@@ -0,0 +1,52 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/notation"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type ForwardedHeader struct {
+	SenderName string `astm:"5.1"`
+	Raw        astm.RawFields
+}
+type ForwardedOrder struct {
+	SpecimenID string `astm:"3"`
+	Raw        astm.RawFields
+}
+type ForwardedTerminator struct {
+	Raw astm.RawFields
+}
+type ForwardedMessage struct {
+	Header     ForwardedHeader     `astm:"H"`
+	Orders     []ForwardedOrder    `astm:"O"`
+	Terminator ForwardedTerminator `astm:"L"`
+}
+
+func TestRawFieldsRoundTrip(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&|||Analyzer^1.0^SN42|||||LIS||P|LIS2-A2|20240101120000",
+		"O|1|SPECIMEN1||^^^TSH\\^^^FT4|R||||||N",
+		"O|2|SPECIMEN2||^^^CRP|S",
+		"L|1|N",
+	}
+	config.Notation = notation.Short
+	var message ForwardedMessage
+	// Act
+	err := astm.Unmarshal([]byte(strings.Join(lines, "\n")), &message, config)
+	assert.Nil(t, err)
+	message.Orders[1].SpecimenID = "REWRITTEN"
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, result, 4)
+	assert.Equal(t, lines[0], string(result[0]))
+	assert.Equal(t, lines[1], string(result[1]))
+	assert.Equal(t, "O|2|REWRITTEN||^^^CRP|S", string(result[2]))
+	assert.Equal(t, lines[3], string(result[3]))
+	// Teardown
+	teardown()
+}
@@ -0,0 +1,41 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type ReagentCommentRecord struct {
+	Names       []string `astm:"3.1"`
+	Lots        []string `astm:"3.3"`
+	Expirations []string `astm:"3.4"`
+	CardType    string   `astm:"4.1"`
+	CardLot     string   `astm:"4.3"`
+}
+type ReagentCommentMessage struct {
+	Header   struct{}               `astm:"H"`
+	Comments []ReagentCommentRecord `astm:"C"`
+}
+
+func TestRepeatedComponentRoundTrip(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&",
+		"C|1|ID-Diluent 2^^05761.03.12^20240131\\^^^|CAS^5005352062212117030^50053.52.06^20221231^4||",
+	}
+	var message ReagentCommentMessage
+	// Act
+	err := astm.Unmarshal([]byte(strings.Join(lines, "\n")), &message, config)
+	assert.Nil(t, err)
+	result, err := astm.Marshal(message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, message.Comments, 1)
+	assert.Equal(t, []string{"ID-Diluent 2", ""}, message.Comments[0].Names)
+	assert.Equal(t, []string{"05761.03.12", ""}, message.Comments[0].Lots)
+	assert.Equal(t, []string{"20240131", ""}, message.Comments[0].Expirations)
+	assert.Equal(t, "50053.52.06", message.Comments[0].CardLot)
+	assert.Equal(t, "C|1|ID-Diluent 2^^05761.03.12^20240131\\^^^|CAS^^50053.52.06", string(result[1]))
+}
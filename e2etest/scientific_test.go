@@ -0,0 +1,106 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/notation"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScientificMessageRoundTrip(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&||||||||||P|E1394-97|20240315123000",
+		"S|1|ELISA|Analyzer 1|Lot 42|Ratio|QC1|SERUM^VEN||Tube|SPEC1|SARSCOV2IGA|8.11|Ratio|20240314080000|20240315110000||U07.1|19800101|F|W",
+		"L|1|N",
+	}
+	message := strings.Join(lines, string(config.LineSeparator)) + string(config.LineSeparator)
+	var result lis02a2.ScientificMessage
+	config.Notation = notation.Short
+	// Act
+	err := astm.Unmarshal([]byte(message), &result, config)
+	output, marshalErr := astm.Marshal(result, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, result.Scientifics, 1)
+	scientific := result.Scientifics[0]
+	assert.Equal(t, "ELISA", scientific.AnalyticalMethod)
+	assert.Equal(t, "VEN", scientific.SpecimenSource)
+	assert.Equal(t, "SPEC1", scientific.SpecimenID)
+	assert.Equal(t, "8.11", scientific.Result)
+	assert.Equal(t, time.Date(2024, 3, 15, 11, 0, 0, 0, config.TimeLocation).UTC(), scientific.ResultDateTime)
+	assert.Equal(t, time.Date(1980, 1, 1, 0, 0, 0, 0, config.TimeLocation), scientific.PatientBirthdate)
+	assert.Equal(t, "W", scientific.PatientRace)
+	assert.Nil(t, marshalErr)
+	for i := range lines {
+		assert.Equal(t, lines[i], string(output[i]))
+	}
+	teardown()
+}
+
+func TestMarshalQueryResponseMessage(t *testing.T) {
+	// Arrange
+	header := lis02a2.Header{Version: "E1394-97"}
+	patientOrders := []lis02a2.PatientOrder{{
+		Patient: lis02a2.Patient{LabAssignedPatientID: "PAT1"},
+		Orders:  []lis02a2.Order{{SpecimenID: "SPEC1", ReportType: lis02a2.ReportTypeQueryResponse}},
+	}}
+	response := lis02a2.NewQueryResponseMessage(header, patientOrders)
+	config.Notation = notation.Short
+	// Act
+	lines, err := astm.Marshal(response, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, lines, 4)
+	assert.Equal(t, "P|1||PAT1", string(lines[1]))
+	assert.Equal(t, "O|1|SPEC1|||||||||||||||||||||||Q", string(lines[2]))
+	assert.Equal(t, "L|1|F", string(lines[3]))
+	teardown()
+}
+
+func TestMarshalQueryResponseMessageWithoutInformation(t *testing.T) {
+	// Arrange
+	response := lis02a2.NewStructuredQueryResponseMessage(lis02a2.StructuredHeader{}, nil)
+	// Act
+	lines, err := astm.Marshal(response, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "L|1|I", string(lines[1]))
+	teardown()
+}
+
+func TestMarshalQueryErrorResponseMessage(t *testing.T) {
+	// Arrange
+	response := lis02a2.NewQueryErrorResponseMessage(lis02a2.Header{})
+	// Act
+	lines, err := astm.Marshal(response, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "L|1|Q", string(lines[len(lines)-1]))
+	teardown()
+}
+
+func TestUnmarshalQueryResponseMessage(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&||||||||||P|E1394-97|20240315123000",
+		"P|1||PAT1",
+		"O|1|SPEC1||^^^SARSCOV2IGA|R",
+		"O|2|SPEC1||^^^SARSCOV2IGG|R",
+		"L|1|F",
+	}
+	message := strings.Join(lines, string(config.LineSeparator)) + string(config.LineSeparator)
+	var result lis02a2.QueryResponseMessage
+	// Act
+	err := astm.Unmarshal([]byte(message), &result, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, result.PatientOrders, 1)
+	assert.Len(t, result.PatientOrders[0].Orders, 2)
+	assert.Equal(t, lis02a2.TerminatorCodeQueryProcessed, result.Terminator.TerminatorCode)
+	teardown()
+}
@@ -0,0 +1,241 @@
+package e2e
+
+import (
+	"context"
+	"github.com/blutspende/bloodlab-common/messagetype"
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/role"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/blutspende/go-astm/v3/transmission"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+// Start a server on a random local port, returns the address and the result of Serve
+func helperStartServer(t *testing.T, ctx context.Context, handler astm.Handler, serverConfig astmmodels.ServerConfiguration) (string, chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	server := astm.NewServer(handler, serverConfig)
+	result := make(chan error, 1)
+	go func() {
+		result <- server.Serve(ctx, listener)
+	}()
+	return listener.Addr().String(), result
+}
+
+// Connect to the server as an instrument
+func helperConnectInstrument(t *testing.T, ctx context.Context, address string) (net.Conn, *transmission.Connection) {
+	conn, err := net.Dial("tcp", address)
+	assert.Nil(t, err)
+	transmissionConfig := astmmodels.DefaultTransmissionConfiguration
+	transmissionConfig.Role = role.Instrument
+	connection := transmission.NewConnection(conn, transmissionConfig)
+	go connection.Run(ctx)
+	return conn, connection
+}
+
+func TestServerAnswersQuery(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	serverConfig := astmmodels.DefaultServerConfiguration
+	serverConfig.Configuration = config
+	received := make(chan messagetype.MessageType, 1)
+	handler := func(ctx context.Context, session *astm.Session, messageData []byte, messageType messagetype.MessageType) {
+		var query lis02a2.QueryMessage
+		err := astm.Unmarshal(messageData, &query, session.Configuration())
+		assert.Nil(t, err)
+		var order lis02a2.OrderMessage
+		order.PatientOrders = []lis02a2.PatientOrder{{Orders: []lis02a2.Order{{SpecimenID: query.Queries[0].StartingRangeIDNumber}}}}
+		order.Terminator.TerminatorCode = "N"
+		err = session.Reply(ctx, order)
+		assert.Nil(t, err)
+		received <- messageType
+	}
+	address, _ := helperStartServer(t, ctx, handler, serverConfig)
+	conn, instrument := helperConnectInstrument(t, ctx, address)
+	defer conn.Close()
+	var query lis02a2.QueryMessage
+	query.Queries = []lis02a2.Query{{StartingRangeIDNumber: "SPECIMEN1"}}
+	query.Terminator.TerminatorCode = "N"
+	lines, _ := astm.Marshal(query, config)
+	// Act
+	err := instrument.Send(ctx, lines)
+	assert.Nil(t, err)
+	replyData, err := instrument.Receive(ctx)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, messagetype.Query, <-received)
+	var order lis02a2.OrderMessage
+	err = astm.Unmarshal(replyData, &order, config)
+	assert.Nil(t, err)
+	assert.Len(t, order.PatientOrders, 1)
+	assert.Equal(t, "SPECIMEN1", order.PatientOrders[0].Orders[0].SpecimenID)
+}
+
+func TestServerPerConnectionConfiguration(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	serverConfig := astmmodels.DefaultServerConfiguration
+	serverConfig.ConfigurationProvider = func(remoteAddress net.Addr) astmmodels.Configuration {
+		connectionConfig := config
+		connectionConfig.Notation = "SHORT"
+		return connectionConfig
+	}
+	received := make(chan astmmodels.Configuration, 1)
+	handler := func(ctx context.Context, session *astm.Session, messageData []byte, messageType messagetype.MessageType) {
+		received <- session.Configuration()
+	}
+	address, _ := helperStartServer(t, ctx, handler, serverConfig)
+	conn, instrument := helperConnectInstrument(t, ctx, address)
+	defer conn.Close()
+	// Act
+	err := instrument.Send(ctx, [][]byte{[]byte("H|\\^&"), []byte("L|1|N")})
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "SHORT", (<-received).Notation)
+}
+
+func TestServerGracefulShutdown(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	handler := func(context.Context, *astm.Session, []byte, messagetype.MessageType) {}
+	address, result := helperStartServer(t, ctx, handler, astmmodels.DefaultServerConfiguration)
+	conn, err := net.Dial("tcp", address)
+	assert.Nil(t, err)
+	defer conn.Close()
+	// Act
+	cancel()
+	// Assert
+	select {
+	case err = <-result:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	assert.NotNil(t, err)
+}
+
+func TestServerConnectionLimit(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	serverConfig := astmmodels.DefaultServerConfiguration
+	serverConfig.MaxConnections = 1
+	handler := func(context.Context, *astm.Session, []byte, messagetype.MessageType) {}
+	address, _ := helperStartServer(t, ctx, handler, serverConfig)
+	first, err := net.Dial("tcp", address)
+	assert.Nil(t, err)
+	second, err := net.Dial("tcp", address)
+	assert.Nil(t, err)
+	defer second.Close()
+	reply := make([]byte, 1)
+	// Act
+	_, _ = second.Write([]byte{0x05})
+	second.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	_, errWhileFull := second.Read(reply)
+	first.Close()
+	second.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, errAfterRelease := second.Read(reply)
+	// Assert
+	assert.NotNil(t, errWhileFull)
+	assert.Nil(t, errAfterRelease)
+	assert.Equal(t, byte(0x06), reply[0])
+}
+
+func TestServerRepliesWhileInstrumentSends(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	serverConfig := astmmodels.DefaultServerConfiguration
+	serverConfig.Configuration = config
+	secondSent := make(chan struct{})
+	replied := make(chan error, 1)
+	received := make(chan string, 2)
+	handler := func(ctx context.Context, session *astm.Session, messageData []byte, messageType messagetype.MessageType) {
+		var query lis02a2.QueryMessage
+		_ = astm.Unmarshal(messageData, &query, session.Configuration())
+		received <- query.Queries[0].StartingRangeIDNumber
+		if query.Queries[0].StartingRangeIDNumber != "SPECIMEN1" {
+			return
+		}
+		// Reply only after the instrument transmitted its next message
+		<-secondSent
+		replyCtx, replyCancel := context.WithTimeout(ctx, 2*time.Second)
+		defer replyCancel()
+		var order lis02a2.OrderMessage
+		order.PatientOrders = []lis02a2.PatientOrder{{Orders: []lis02a2.Order{{SpecimenID: "SPECIMEN1"}}}}
+		replied <- session.Reply(replyCtx, order)
+	}
+	address, _ := helperStartServer(t, ctx, handler, serverConfig)
+	conn, instrument := helperConnectInstrument(t, ctx, address)
+	defer conn.Close()
+	var query lis02a2.QueryMessage
+	query.Queries = []lis02a2.Query{{StartingRangeIDNumber: "SPECIMEN1"}}
+	first, _ := astm.Marshal(query, config)
+	query.Queries[0].StartingRangeIDNumber = "SPECIMEN2"
+	second, _ := astm.Marshal(query, config)
+	// Act
+	assert.Nil(t, instrument.Send(ctx, first))
+	assert.Nil(t, instrument.Send(ctx, second))
+	close(secondSent)
+	replyData, err := instrument.Receive(ctx)
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, <-replied)
+	var order lis02a2.OrderMessage
+	err = astm.Unmarshal(replyData, &order, config)
+	assert.Nil(t, err)
+	assert.Equal(t, "SPECIMEN1", order.PatientOrders[0].Orders[0].SpecimenID)
+	assert.Equal(t, "SPECIMEN1", <-received)
+	assert.Equal(t, "SPECIMEN2", <-received)
+}
+
+func TestServerFullMessageQueueRejectsMessages(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	serverConfig := astmmodels.DefaultServerConfiguration
+	serverConfig.MessageQueueSize = 1
+	handled := make(chan string, 4)
+	release := make(chan struct{})
+	handler := func(ctx context.Context, session *astm.Session, messageData []byte, messageType messagetype.MessageType) {
+		handled <- string(messageData)
+		<-release
+	}
+	address, _ := helperStartServer(t, ctx, handler, serverConfig)
+	conn, err := net.Dial("tcp", address)
+	assert.Nil(t, err)
+	defer conn.Close()
+	transmissionConfig := astmmodels.DefaultTransmissionConfiguration
+	transmissionConfig.Role = role.Instrument
+	transmissionConfig.MaxRetransmissions = 1
+	instrument := transmission.NewConnection(conn, transmissionConfig)
+	go instrument.Run(ctx)
+	message := func(id string) [][]byte {
+		return [][]byte{[]byte("H|\\^&"), []byte("L|1|" + id)}
+	}
+	// Act
+	errFirst := instrument.Send(ctx, message("1"))
+	firstHandled := <-handled
+	errQueued := instrument.Send(ctx, message("2"))
+	errWhileFull := instrument.Send(ctx, message("3"))
+	close(release)
+	secondHandled := <-handled
+	errAfterRelease := instrument.Send(ctx, message("4"))
+	// Assert
+	assert.Nil(t, errFirst)
+	assert.Nil(t, errQueued)
+	assert.ErrorIs(t, errWhileFull, errmsg.ErrTransmissionReceiverBusy)
+	assert.Nil(t, errAfterRelease)
+	assert.Equal(t, "H|\\^&\rL|1|1\r", firstHandled)
+	assert.Equal(t, "H|\\^&\rL|1|2\r", secondHandled)
+	assert.Equal(t, "H|\\^&\rL|1|4\r", <-handled)
+}
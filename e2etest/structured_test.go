@@ -0,0 +1,81 @@
+package e2e
+
+import (
+	"github.com/blutspende/go-astm/v3"
+	"github.com/blutspende/go-astm/v3/enums/notation"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalStructuredQueryMessage(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&|||LIS^1.0|Street 1^Berlin^^10115^DE|||||||E1394-97|20240315123000",
+		"Q|1|PAT1^SPEC1|PAT1^SPEC2|^^^SARSCOV2IGA\\^^^SARSCOV2IGG|R|20240301|20240315120000|Doe^John|030123\\030456|||O",
+		"L|1|N",
+	}
+	message := strings.Join(lines, string(config.LineSeparator)) + string(config.LineSeparator)
+	var result lis02a2.StructuredQueryMessage
+	// Act
+	err := astm.Unmarshal([]byte(message), &result, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "Berlin", result.Header.SenderStreetAddress.City)
+	assert.Equal(t, "DE", result.Header.SenderStreetAddress.CountryCode)
+	query := result.Queries[0]
+	assert.Equal(t, "PAT1", query.StartingRangeIDNumber.PatientID)
+	assert.Equal(t, "SPEC1", query.StartingRangeIDNumber.SpecimenID)
+	assert.Equal(t, "SPEC2", query.EndingRangeIDNumber.SpecimenID)
+	assert.Len(t, query.UniversalTestIDs, 2)
+	assert.Equal(t, "SARSCOV2IGA", query.UniversalTestIDs[0].ManufacturersTestType)
+	assert.Equal(t, "SARSCOV2IGG", query.UniversalTestIDs[1].ManufacturersTestType)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, config.TimeLocation).UTC(), query.BeginningRequestResultsDateTime)
+	assert.Equal(t, time.Date(2024, 3, 15, 12, 0, 0, 0, config.TimeLocation).UTC(), query.EndingRequestResultsDateTime)
+	assert.Equal(t, "Doe", query.RequestingPhysicianName.LastName)
+	assert.Equal(t, "John", query.RequestingPhysicianName.FirstName)
+	assert.Equal(t, []string{"030123", "030456"}, query.RequestingPhysicianTelephone)
+	assert.Equal(t, lis02a2.RequestInformationStatusOrdersOnly, query.RequestInformationStatus)
+	teardown()
+}
+
+func TestStructuredOrderMessageRoundTrip(t *testing.T) {
+	// Arrange
+	lines := []string{
+		"H|\\^&||||||||||P|E1394-97|20240315123000",
+		"P|1||PAT1||Doe^Jane^M||19800101|F||Street 1^Berlin^^10115^DE||030123|D1^House^Gregory\\D2^Wilson^James|||180^cm|72.5^kg||||||20240310^20240314",
+		"O|1|SPEC1||^^^SARSCOV2IGA\\^^^SARSCOV2IGG|R||20240314080000||5^ml||N|||20240314090000|SERUM^VEN|Doe^John",
+		"L|1|N",
+	}
+	message := strings.Join(lines, string(config.LineSeparator)) + string(config.LineSeparator)
+	var result lis02a2.StructuredOrderMessage
+	config.Notation = notation.Short
+	// Act
+	err := astm.Unmarshal([]byte(message), &result, config)
+	output, marshalErr := astm.Marshal(result, config)
+	// Assert
+	assert.Nil(t, err)
+	patient := result.PatientOrders[0].Patient
+	assert.Equal(t, "Jane", patient.Name.FirstName)
+	assert.Equal(t, "10115", patient.Address.ZipCode)
+	assert.Len(t, patient.AttendingPhysicianID, 2)
+	assert.Equal(t, "D2", patient.AttendingPhysicianID[1].ID)
+	assert.Equal(t, "Wilson", patient.AttendingPhysicianID[1].LastName)
+	assert.Equal(t, "72.5", patient.Weight.Value.String())
+	assert.Equal(t, "kg", patient.Weight.Units)
+	assert.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, config.TimeLocation), patient.AdmissionDate)
+	assert.Equal(t, time.Date(2024, 3, 14, 0, 0, 0, 0, config.TimeLocation), patient.DischargeDate)
+	order := result.PatientOrders[0].Orders[0]
+	assert.Len(t, order.UniversalTestIDs, 2)
+	assert.Equal(t, "5", order.CollectionVolume.Value.String())
+	assert.Equal(t, "ml", order.CollectionVolume.Units)
+	assert.Equal(t, time.Date(2024, 3, 14, 9, 0, 0, 0, config.TimeLocation).UTC(), order.DateTimeSpecimenReceived)
+	assert.Equal(t, "Doe", order.OrderingPhysician.LastName)
+	assert.Nil(t, marshalErr)
+	for i := range lines {
+		assert.Equal(t, lines[i], string(output[i]))
+	}
+	teardown()
+}
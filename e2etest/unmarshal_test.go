@@ -1,13 +1,16 @@
 package e2e
 
 import (
+	"fmt"
 	"github.com/blutspende/bloodlab-common/encoding"
 	"github.com/blutspende/bloodlab-common/timezone"
 	"github.com/blutspende/go-astm/v3"
 	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
 	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/encoding/charmap"
+	"sync"
 	"testing"
 	"time"
 )
@@ -688,3 +691,183 @@ L|1|N `
 	assert.Nil(t, err)
 	assert.Equal(t, "ABOD|Full&Interp", message.PatientGroups[0].OrderGroups[0].ResultGroups[0].Result.UniversalTestID.ManufacturersTestType)
 }
+
+type FloatResultRecord struct {
+	Value float64 `astm:"4"`
+}
+type FloatResultMessage struct {
+	Header     lis02a2.Header      `astm:"H"`
+	Results    []FloatResultRecord `astm:"R"`
+	Terminator lis02a2.Terminator  `astm:"L"`
+}
+
+func TestUnmarshalParseErrorPosition(t *testing.T) {
+	// Arrange
+	messageString := "H|\\^&\n"
+	messageString += "R|1||1.5\n"
+	messageString += "R|2||2.5\n"
+	messageString += "R|3||abc\n"
+	messageString += "L|1|N\n"
+	var message FloatResultMessage
+	// Act
+	err := astm.Unmarshal([]byte(messageString), &message, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	var parseError *astm.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.Equal(t, 4, parseError.Line)
+	assert.Equal(t, "R", parseError.RecordType)
+	assert.Equal(t, 3, parseError.SequenceNumber)
+	assert.Equal(t, 4, parseError.FieldPos)
+	assert.Equal(t, "Results[2].Value", parseError.FieldPath)
+	assert.Equal(t, "abc", parseError.Value)
+}
+
+func TestUnmarshalLenientParsing(t *testing.T) {
+	// Arrange
+	messageString := "H|\\^&\n"
+	messageString += "R|1||1.5\n"
+	messageString += "R|2||x.5\n"
+	messageString += "R|3||abc\n"
+	messageString += "L|1|N\n"
+	var message FloatResultMessage
+	config.LenientParsing = true
+	// Act
+	err := astm.Unmarshal([]byte(messageString), &message, config)
+	// Assert
+	var parseErrors astm.ParseErrors
+	assert.ErrorAs(t, err, &parseErrors)
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	assert.Len(t, parseErrors, 2)
+	assert.Equal(t, 3, parseErrors[0].Line)
+	assert.Equal(t, "Results[1].Value", parseErrors[0].FieldPath)
+	assert.Equal(t, 4, parseErrors[1].Line)
+	assert.Equal(t, "Results[2].Value", parseErrors[1].FieldPath)
+	assert.Len(t, message.Results, 3)
+	assert.Equal(t, 1.5, message.Results[0].Value)
+	assert.Equal(t, 0.0, message.Results[1].Value)
+	assert.Equal(t, lis02a2.TerminatorCodeNormal, message.Terminator.TerminatorCode)
+	// Teardown
+	teardown()
+}
+
+func TestUnmarshalLenientParsingMissingHeader(t *testing.T) {
+	// Arrange
+	messageString := "R|1||abc\n"
+	messageString += "L|1|N\n"
+	var message FloatResultMessage
+	config.LenientParsing = true
+	// Act
+	err := astm.Unmarshal([]byte(messageString), &message, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrStructureParsingLineTypeNameMismatch)
+	assert.IsType(t, &astm.ParseError{}, err)
+	// Teardown
+	teardown()
+}
+
+func TestUnmarshalWithReport(t *testing.T) {
+	// Arrange
+	messageString := "H|\\^&|||Instrument^1.0\n"
+	messageString += "R|1||1.5|mg/dl\n"
+	messageString += "L|1|N\n"
+	messageString += "C|1|I|trailing comment\n"
+	var message FloatResultMessage
+	// Act
+	report, err := astm.UnmarshalWithReport([]byte(messageString), &message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, message.Results[0].Value)
+	assert.Len(t, report.UnmappedData, 2)
+	assert.Equal(t, 2, report.UnmappedData[0].Line)
+	assert.Equal(t, "R", report.UnmappedData[0].RecordType)
+	assert.Equal(t, 5, report.UnmappedData[0].FieldPos)
+	assert.Equal(t, "mg/dl", report.UnmappedData[0].Value)
+	assert.Equal(t, 4, report.UnmappedData[1].Line)
+	assert.Equal(t, 0, report.UnmappedData[1].FieldPos)
+	assert.Equal(t, "C|1|I|trailing comment", report.UnmappedData[1].Value)
+}
+
+func TestUnmarshalWithReportConcurrent(t *testing.T) {
+	// Arrange
+	messages := make([]string, 8)
+	for i := range messages {
+		messages[i] = "H|\\^&|||Instrument^1.0\n"
+		messages[i] += fmt.Sprintf("R|1||1.5|unit%d\n", i)
+		messages[i] += "L|1|N\n"
+	}
+	reports := make([]*astmmodels.UnmarshalReport, len(messages))
+	errs := make([]error, len(messages))
+	var wg sync.WaitGroup
+	// Act
+	for i := range messages {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var message FloatResultMessage
+			reports[i], errs[i] = astm.UnmarshalWithReport([]byte(messages[i]), &message, config)
+		}(i)
+	}
+	wg.Wait()
+	// Assert
+	for i := range messages {
+		assert.Nil(t, errs[i])
+		assert.Len(t, reports[i].UnmappedData, 1)
+		assert.Equal(t, fmt.Sprintf("unit%d", i), reports[i].UnmappedData[0].Value)
+	}
+}
+
+type PointerResultRecord struct {
+	Value    *float64 `astm:"4"`
+	Dilution *uint16  `astm:"5"`
+	Repeated *bool    `astm:"6"`
+}
+type PointerResultMessage struct {
+	Header     lis02a2.Header        `astm:"H"`
+	Results    []PointerResultRecord `astm:"R"`
+	Terminator lis02a2.Terminator    `astm:"L"`
+}
+
+func TestUnmarshalPointerFields(t *testing.T) {
+	// Arrange
+	messageString := "H|\\^&\n"
+	messageString += "R|1||0|10|N\n"
+	messageString += "R|2||||\n"
+	messageString += "L|1|N\n"
+	var message PointerResultMessage
+	// Act
+	err := astm.Unmarshal([]byte(messageString), &message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, *message.Results[0].Value)
+	assert.Equal(t, uint16(10), *message.Results[0].Dilution)
+	assert.False(t, *message.Results[0].Repeated)
+	assert.Nil(t, message.Results[1].Value)
+	assert.Nil(t, message.Results[1].Dilution)
+	assert.Nil(t, message.Results[1].Repeated)
+}
+
+type DatedResultRecord struct {
+	Started   time.Time `astm:"12,longdate"`
+	Completed time.Time `astm:"13,longdate"`
+}
+type DatedResultMessage struct {
+	Header     lis02a2.Header      `astm:"H"`
+	Results    []DatedResultRecord `astm:"R"`
+	Terminator lis02a2.Terminator  `astm:"L"`
+}
+
+func TestUnmarshalPartialPrecisionDates(t *testing.T) {
+	// Arrange
+	messageString := "H|\\^&||||||||||||20240315123000+0000\n"
+	messageString += "R|1|^^^TSH|1.5|||||F|||202403151215|20240315122030.5\n"
+	messageString += "L|1|N\n"
+	var message DatedResultMessage
+	// Act
+	err := astm.Unmarshal([]byte(messageString), &message, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 15, 12, 30, 0, 0, time.UTC), message.Header.DateAndTime)
+	assert.Equal(t, time.Date(2024, 3, 15, 11, 15, 0, 0, time.UTC), message.Results[0].Started)
+	assert.Equal(t, time.Date(2024, 3, 15, 11, 20, 30, 500000000, time.UTC), message.Results[0].Completed)
+}
@@ -0,0 +1,77 @@
+package astm
+
+import (
+	"github.com/blutspende/go-astm/v3/enums/lineseparator"
+	"github.com/blutspende/go-astm/v3/functions"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"io"
+)
+
+// Encoder writes ASTM messages one after the other to a stream
+type Encoder struct {
+	writer           io.Writer
+	configuration    []astmmodels.Configuration
+	recordTerminator string
+	framed           bool
+}
+
+func NewEncoder(writer io.Writer, configuration ...astmmodels.Configuration) *Encoder {
+	// The records are terminated with CR as the standard defines, or with the line separator configured without auto-detection
+	recordTerminator := lineseparator.CR
+	if len(configuration) > 0 && configuration[0].LineSeparator != "" && !configuration[0].AutoDetectLineSeparator {
+		recordTerminator = configuration[0].LineSeparator
+	}
+	return &Encoder{
+		writer:           writer,
+		configuration:    configuration,
+		recordTerminator: recordTerminator,
+		framed:           false,
+	}
+}
+
+// SetRecordTerminator overrides the characters written after every record
+func (e *Encoder) SetRecordTerminator(recordTerminator string) {
+	e.recordTerminator = recordTerminator
+}
+
+// SetFraming enables wrapping the records into LIS01-A2 frames (the record terminator is always CR then)
+func (e *Encoder) SetFraming(framed bool) {
+	e.framed = framed
+}
+
+func (e *Encoder) Encode(sourceStruct interface{}) (err error) {
+	// Marshal the message into encoded lines
+	lines, err := Marshal(sourceStruct, e.configuration...)
+	if err != nil {
+		return err
+	}
+	// Assemble the whole message, so it is written at once
+	var output []byte
+	if e.framed {
+		frames, err := functions.BuildFrames(lines, 1)
+		if err != nil {
+			return err
+		}
+		for _, frame := range frames {
+			output = append(output, frame...)
+		}
+	} else {
+		for _, line := range lines {
+			output = append(output, line...)
+			output = append(output, e.recordTerminator...)
+		}
+	}
+	// Write the message and flush it if the writer is buffered
+	_, err = e.writer.Write(output)
+	if err != nil {
+		return err
+	}
+	if flusher, ok := e.writer.(interface{ Flush() error }); ok {
+		err = flusher.Flush()
+		if err != nil {
+			return err
+		}
+	}
+	// Return nil if everything went well
+	return nil
+}
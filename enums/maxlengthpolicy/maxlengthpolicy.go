@@ -0,0 +1,5 @@
+package maxlengthpolicy
+
+const Error string = "ERROR"
+const Truncate string = "TRUNCATE"
+const TruncateWithWarning string = "TRUNCATE_WITH_WARNING"
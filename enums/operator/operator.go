@@ -0,0 +1,6 @@
+package operator
+
+const LessThan string = "LESS_THAN"
+const LessOrEqual string = "LESS_OR_EQUAL"
+const GreaterThan string = "GREATER_THAN"
+const GreaterOrEqual string = "GREATER_OR_EQUAL"
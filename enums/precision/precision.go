@@ -0,0 +1,9 @@
+package precision
+
+const Year string = "YEAR"
+const Month string = "MONTH"
+const Day string = "DAY"
+const Hour string = "HOUR"
+const Minute string = "MINUTE"
+const Second string = "SECOND"
+const FractionalSecond string = "FRACTIONAL_SECOND"
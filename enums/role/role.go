@@ -0,0 +1,4 @@
+package role
+
+const Computer string = "COMPUTER"
+const Instrument string = "INSTRUMENT"
@@ -0,0 +1,6 @@
+package valuekind
+
+const Numeric string = "NUMERIC" // e.g. 1.50, <0.05, >100
+const Titer string = "TITER"     // e.g. 1:160
+const Grade string = "GRADE"     // e.g. 3+, +++
+const Text string = "TEXT"       // e.g. POS, Negative
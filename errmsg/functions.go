@@ -14,6 +14,28 @@ var (
 	ErrLineProcessingNoLineSeparator  = errors.New("separator has to be provided if auto-detect is disabled")
 )
 
+// Framing
+var (
+	ErrFramingEmptyInput          = errors.New("empty input")
+	ErrFramingInvalidFrame        = errors.New("invalid frame")
+	ErrFramingChecksumMismatch    = errors.New("checksum mismatch")
+	ErrFramingFrameNumberMismatch = errors.New("frame number mismatch")
+	ErrFramingIncompleteMessage   = errors.New("incomplete message")
+)
+
+// Transmission
+var (
+	ErrTransmissionTimeout                   = errors.New("transmission timeout")
+	ErrTransmissionReceiverBusy              = errors.New("receiver busy")
+	ErrTransmissionMaxRetransmissionsReached = errors.New("max retransmissions reached")
+	ErrTransmissionConnectionClosed          = errors.New("connection closed")
+)
+
+// Field types
+var (
+	ErrDecimalInvalidFormat = errors.New("invalid decimal format")
+)
+
 // AnnotationParsing
 var (
 	ErrAnnotationParsingMissingAstmAnnotation        = errors.New("astm annotation missing")
@@ -21,8 +43,10 @@ var (
 	ErrAnnotationParsingInvalidAstmAttribute         = errors.New("invalid astm attribute")
 	ErrAnnotationParsingInvalidAstmAttributeFormat   = errors.New("invalid astm attribute format")
 	ErrAnnotationParsingInvalidInputStruct           = errors.New("invalid input struct")
-	ErrAnnotationParsingIllegalComponentArray        = errors.New("component array is not allowed")
 	ErrAnnotationParsingIllegalComponentSubstructure = errors.New("component substructure is not allowed")
+	ErrAnnotationParsingSubstructureTooDeep          = errors.New("substructure nesting is deeper than the sub-components")
+	// Deprecated: component arrays are supported, this error is no longer returned
+	ErrAnnotationParsingIllegalComponentArray = errors.New("component array is not allowed")
 )
 
 // LineParsing
@@ -37,6 +61,8 @@ var (
 	ErrLineParsingNonSettableField            = errors.New("field is not settable")
 	ErrLineParsingDataParsingError            = errors.New("data parsing error")
 	ErrLineParsingInvalidDateFormat           = errors.New("invalid date format")
+	ErrLineParsingMaxLengthExceeded           = errors.New("value exceeds maximum length")
+	ErrLineParsingInvalidEnumValue            = errors.New("value is not one of the allowed values")
 	ErrLineParsingUnsupportedDataType         = errors.New("unsupported data type")
 	ErrLineParsingReservedFieldPosReference   = errors.New("field position 1 and 2 are reserved")
 )
@@ -54,4 +80,6 @@ var (
 	ErrLineBuildingUsupportedDataType          = errors.New("unsupported data type")
 	ErrLineBuildingReservedFieldPosReference   = errors.New("field position 1 and 2 are reserved")
 	ErrLineBuildingInvalidLengthAttributeValue = errors.New("invalid length attribute value")
+	ErrLineBuildingMaxLengthExceeded           = errors.New("value exceeds maximum length")
+	ErrLineBuildingInvalidEnumValue            = errors.New("value is not one of the allowed values")
 )
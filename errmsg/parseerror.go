@@ -0,0 +1,82 @@
+package errmsg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError wraps a parsing error with the position of the problem in the message
+// Positions are 1-based, 0 (or empty) means that the position is not applicable or unknown
+type ParseError struct {
+	Err            error
+	Line           int
+	RecordType     string
+	SequenceNumber int
+	FieldPos       int
+	RepeatIndex    int
+	ComponentPos   int
+	FieldPath      string
+	Value          string
+}
+
+func (e *ParseError) Error() string {
+	result := e.Err.Error()
+	if e.Line > 0 {
+		result += fmt.Sprintf(" @ln %d", e.Line)
+	}
+	// Collect the known details of the position
+	details := make([]string, 0)
+	if e.RecordType != "" {
+		record := e.RecordType
+		if e.SequenceNumber > 0 {
+			record += "|" + strconv.Itoa(e.SequenceNumber)
+		}
+		details = append(details, "record "+record)
+	}
+	if e.FieldPos > 0 {
+		field := strconv.Itoa(e.FieldPos)
+		if e.ComponentPos > 0 {
+			field += "." + strconv.Itoa(e.ComponentPos)
+		}
+		details = append(details, "field "+field)
+	} else if e.ComponentPos > 0 {
+		details = append(details, "component "+strconv.Itoa(e.ComponentPos))
+	}
+	if e.RepeatIndex > 0 {
+		details = append(details, "repeat "+strconv.Itoa(e.RepeatIndex))
+	}
+	if e.FieldPath != "" {
+		details = append(details, "struct field "+e.FieldPath)
+	}
+	if e.Value != "" {
+		details = append(details, fmt.Sprintf("value %q", e.Value))
+	}
+	if len(details) > 0 {
+		result += " (" + strings.Join(details, ", ") + ")"
+	}
+	return result
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors collects the parsing errors that did not stop the parsing (in lenient parsing mode)
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, parseError := range e {
+		messages[i] = parseError.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e ParseErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, parseError := range e {
+		errs[i] = parseError
+	}
+	return errs
+}
@@ -0,0 +1,30 @@
+package astm
+
+import (
+	"github.com/blutspende/go-astm/v3/functions"
+)
+
+func Frame(records [][]byte) (frames [][]byte, err error) {
+	// Split the records into frames, the frame numbering starts with 1
+	frames, err = functions.BuildFrames(records, 1)
+	if err != nil {
+		return nil, err
+	}
+	// Return the frames and no error if everything went well
+	return frames, nil
+}
+
+func Deframe(framedData []byte) (messageData []byte, err error) {
+	// Split the framed data into frames
+	frames, err := functions.SliceFrames(framedData)
+	if err != nil {
+		return nil, err
+	}
+	// Validate the frames and reassemble the records
+	messageData, err = functions.ParseFrames(frames, 1)
+	if err != nil {
+		return nil, err
+	}
+	// Return the message data and no error if everything went well
+	return messageData, nil
+}
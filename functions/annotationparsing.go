@@ -24,22 +24,22 @@ func ParseAstmFieldAnnotation(input reflect.StructField) (result models.AstmFiel
 	}
 
 	// Determine if the field is an array or not
-	result.IsArray = input.Type.Kind() == reflect.Slice || input.Type.Kind() == reflect.Array
+	// Note: types with custom marshal/unmarshal methods are always handled as a single value
+	result.IsArray = (input.Type.Kind() == reflect.Slice || input.Type.Kind() == reflect.Array) && !hasCustomCodec(input.Type)
 
-	// Determine if the field is a substructure or not (excluding the time.Time type)
+	// Determine if the field is a substructure or not (excluding time.Time and the custom types)
 	var checkType reflect.Type
 	if result.IsArray {
 		checkType = input.Type.Elem()
 	} else {
 		checkType = input.Type
 	}
-	result.IsSubstructure = checkType.Kind() == reflect.Struct && checkType != reflect.TypeOf(time.Time{})
+	result.IsSubstructure = checkType.Kind() == reflect.Struct &&
+		checkType != reflect.TypeOf(time.Time{}) &&
+		!hasCustomCodec(checkType)
 
-	// Check illegal combinations
-	if result.IsComponent && result.IsArray {
-		return models.AstmFieldAnnotation{}, errmsg.ErrAnnotationParsingIllegalComponentArray
-	}
-	if result.IsComponent && result.IsSubstructure {
+	// Check illegal combinations (a sub-component can not contain a substructure)
+	if result.IsSubComponent && result.IsSubstructure {
 		return models.AstmFieldAnnotation{}, errmsg.ErrAnnotationParsingIllegalComponentSubstructure
 	}
 
@@ -58,17 +58,39 @@ func parseAstmFieldAnnotationString(input string) (result models.AstmFieldAnnota
 		constants.AttributeRequired,
 		constants.AttributeLongdate,
 		constants.AttributeLength,
+		constants.AttributeLayout,
+		constants.AttributeDefault,
+		constants.AttributeMaxLength,
+		constants.AttributeEnum,
 	})
 	if err != nil {
 		return models.AstmFieldAnnotation{}, err
 	}
+	// The maximum length must be a positive number
+	if value, exists := result.Attributes[constants.AttributeMaxLength]; exists {
+		if maxLength, err := strconv.Atoi(value); err != nil || maxLength < 1 {
+			return models.AstmFieldAnnotation{}, errmsg.ErrAnnotationParsingInvalidAstmAttributeFormat
+		}
+	}
 
-	// Split field and component (if any) and parse them
+	// The allowed values must not be empty
+	if value, exists := result.Attributes[constants.AttributeEnum]; exists && value == "" {
+		return models.AstmFieldAnnotation{}, errmsg.ErrAnnotationParsingInvalidAstmAttributeFormat
+	}
+
+	// Split field, component and sub-component (if any) and parse them
 	segments := strings.Split(fieldDef, ".")
-	if len(segments) > 2 {
+	if len(segments) > 3 {
 		return models.AstmFieldAnnotation{}, errmsg.ErrAnnotationParsingInvalidAstmAnnotation
 	}
-	if len(segments) == 2 {
+	if len(segments) == 3 {
+		result.IsSubComponent = true
+		result.SubComponentPos, err = strconv.Atoi(segments[2])
+		if err != nil {
+			return models.AstmFieldAnnotation{}, errmsg.ErrAnnotationParsingInvalidAstmAnnotation
+		}
+	}
+	if len(segments) >= 2 {
 		result.IsComponent = true
 		result.ComponentPos, err = strconv.Atoi(segments[1])
 		if err != nil {
@@ -119,13 +141,13 @@ func parseAttributes(input string, valids []string) (result map[string]string, e
 	if input == "" {
 		return result, nil
 	}
-	// Split the input string by commas
-	attributes := strings.Split(input, ",")
+	// Split the input string by commas (an escaped comma belongs to the value)
+	attributes := splitAttributes(input)
 	// Iterate over the attributes and parse them
 	for _, attribute := range attributes {
-		// Split each attribute by the colon
-		attributeParts := strings.Split(attribute, ":")
-		if len(attributeParts) > 2 {
+		// Split each attribute by the colon (a layout or default value can contain colons itself)
+		attributeParts := strings.SplitN(attribute, ":", 2)
+		if len(attributeParts) == 2 && strings.Contains(attributeParts[1], ":") && !isInList(attributeParts[0], []string{constants.AttributeLayout, constants.AttributeDefault}) {
 			return nil, errmsg.ErrAnnotationParsingInvalidAstmAttributeFormat
 		}
 		// Check if the attribute is valid
@@ -143,6 +165,23 @@ func parseAttributes(input string, valids []string) (result map[string]string, e
 	return result, nil
 }
 
+func splitAttributes(input string) (result []string) {
+	// A backslash followed by a comma is a comma in the value (e.g. in a layout with a decimal comma)
+	var builder strings.Builder
+	for i := 0; i < len(input); i++ {
+		if input[i] == '\\' && i+1 < len(input) && input[i+1] == ',' {
+			builder.WriteByte(',')
+			i++
+		} else if input[i] == ',' {
+			result = append(result, builder.String())
+			builder.Reset()
+		} else {
+			builder.WriteByte(input[i])
+		}
+	}
+	return append(result, builder.String())
+}
+
 func splitByFirst(input string, delimiter string) (before string, after string) {
 	index := strings.Index(input, delimiter) // Find the first occurrence of the comma
 	if index == -1 {
@@ -37,6 +37,61 @@ func TestParseAstmFieldAnnotationString_Componented(t *testing.T) {
 	assert.Equal(t, false, result.IsSubstructure)
 	assert.Empty(t, result.Attributes)
 }
+func TestParseAstmFieldAnnotationString_DefaultWithColon(t *testing.T) {
+	// Arrange
+	input := "7,default:12:00"
+	// Act
+	result, err := parseAstmFieldAnnotationString(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "12:00", result.Attributes[constants.AttributeDefault])
+}
+func TestParseAstmFieldAnnotationString_InvalidMaxLength(t *testing.T) {
+	// Arrange
+	input := "3,maxlen:0"
+	// Act
+	_, err := parseAstmFieldAnnotationString(input)
+	// Assert
+	assert.EqualError(t, err, errmsg.ErrAnnotationParsingInvalidAstmAttributeFormat.Error())
+}
+func TestParseAstmFieldAnnotationString_Enum(t *testing.T) {
+	// Arrange
+	input := "3,required,enum:M|F|U"
+	// Act
+	result, err := parseAstmFieldAnnotationString(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "M|F|U", result.Attributes[constants.AttributeEnum])
+}
+func TestParseAstmFieldAnnotationString_EmptyEnum(t *testing.T) {
+	// Arrange
+	input := "3,enum"
+	// Act
+	_, err := parseAstmFieldAnnotationString(input)
+	// Assert
+	assert.EqualError(t, err, errmsg.ErrAnnotationParsingInvalidAstmAttributeFormat.Error())
+}
+func TestParseAstmFieldAnnotationString_SubComponent(t *testing.T) {
+	// Arrange
+	input := "4.2.3"
+	// Act
+	result, err := parseAstmFieldAnnotationString(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 4, result.FieldPos)
+	assert.Equal(t, true, result.IsComponent)
+	assert.Equal(t, 2, result.ComponentPos)
+	assert.Equal(t, true, result.IsSubComponent)
+	assert.Equal(t, 3, result.SubComponentPos)
+}
+func TestParseAstmFieldAnnotationString_InvalidSubComponentNumber(t *testing.T) {
+	// Arrange
+	input := "4.2.x"
+	// Act
+	_, err := parseAstmFieldAnnotationString(input)
+	// Assert
+	assert.EqualError(t, err, errmsg.ErrAnnotationParsingInvalidAstmAnnotation.Error())
+}
 func TestParseAstmFieldAnnotationString_Attributed(t *testing.T) {
 	// Arrange
 	input := "4,required"
@@ -99,7 +154,7 @@ func TestParseAstmFieldAnnotationString_InvalidAttributeFormat(t *testing.T) {
 }
 func TestParseAstmFieldAnnotationString_InvalidAnnotationTooManyParts(t *testing.T) {
 	// Arrange
-	input := "2.1.2"
+	input := "2.1.2.1"
 	// Act
 	_, err := parseAstmFieldAnnotationString(input)
 	// Assert
@@ -107,7 +162,7 @@ func TestParseAstmFieldAnnotationString_InvalidAnnotationTooManyParts(t *testing
 }
 func TestParseAstmFieldAnnotationString_InvalidAnnotationTooManyPartsWithAttribute(t *testing.T) {
 	// Arrange
-	input := "4.1.3,required"
+	input := "4.1.3.2,required"
 	// Act
 	_, err := parseAstmFieldAnnotationString(input)
 	// Assert
@@ -215,14 +270,19 @@ func TestParseAstmFieldAnnotation_SubstructureArray(t *testing.T) {
 	assert.Equal(t, true, result.IsSubstructure)
 	assert.Empty(t, result.Attributes)
 }
-func TestParseAstmFieldAnnotation_IllegalComponentArray(t *testing.T) {
+func TestParseAstmFieldAnnotation_ComponentArray(t *testing.T) {
 	// Arrange
-	var input IllegalComponentArray
+	var input ComponentArray
 	field, _ := reflect.TypeOf(input).FieldByName("ComponentArray")
 	// Act
-	_, err := ParseAstmFieldAnnotation(field)
+	result, err := ParseAstmFieldAnnotation(field)
 	// Assert
-	assert.EqualError(t, err, errmsg.ErrAnnotationParsingIllegalComponentArray.Error())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.FieldPos)
+	assert.Equal(t, true, result.IsArray)
+	assert.Equal(t, true, result.IsComponent)
+	assert.Equal(t, 1, result.ComponentPos)
+	assert.Equal(t, false, result.IsSubstructure)
 }
 func TestParseAstmFieldAnnotation_IllegalComponentSubstructure(t *testing.T) {
 	// Arrange
@@ -233,6 +293,18 @@ func TestParseAstmFieldAnnotation_IllegalComponentSubstructure(t *testing.T) {
 	// Assert
 	assert.EqualError(t, err, errmsg.ErrAnnotationParsingIllegalComponentSubstructure.Error())
 }
+func TestParseAstmFieldAnnotation_ComponentSubstructure(t *testing.T) {
+	// Arrange
+	var input ComponentSubstructure
+	field, _ := reflect.TypeOf(input).FieldByName("ComponentSubstructure")
+	// Act
+	result, err := ParseAstmFieldAnnotation(field)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, true, result.IsComponent)
+	assert.Equal(t, 1, result.ComponentPos)
+	assert.Equal(t, true, result.IsSubstructure)
+}
 func TestParseAstmFieldAnnotation_TimeLine(t *testing.T) {
 	// Arrange
 	var input TimeLine
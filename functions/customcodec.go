@@ -0,0 +1,117 @@
+package functions
+
+import (
+	"encoding"
+	"fmt"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"reflect"
+	"time"
+)
+
+var (
+	astmMarshalerType   = reflect.TypeOf((*astmmodels.AstmMarshaler)(nil)).Elem()
+	astmUnmarshalerType = reflect.TypeOf((*astmmodels.AstmUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func hasCustomCodec(fieldType reflect.Type) bool {
+	// Both value and pointer receivers are accepted
+	for _, checkType := range []reflect.Type{fieldType, reflect.PointerTo(fieldType)} {
+		if checkType.Implements(astmMarshalerType) || checkType.Implements(astmUnmarshalerType) {
+			return true
+		}
+		if hasTextCodec(fieldType) && (checkType.Implements(textMarshalerType) || checkType.Implements(textUnmarshalerType)) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTextCodec(fieldType reflect.Type) bool {
+	// Note: time.Time is a text marshaler, but it has its own ASTM date format
+	return fieldType != reflect.TypeOf(time.Time{})
+}
+
+func unmarshalCustomField(value string, field reflect.Value, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (handled bool, err error) {
+	// Unmarshal methods need a pointer receiver
+	if !field.CanAddr() {
+		return false, nil
+	}
+	target := field.Addr().Interface()
+	// The ASTM unmarshaler takes precedence and gets the value as it is
+	if unmarshaler, ok := target.(astmmodels.AstmUnmarshaler); ok {
+		err = unmarshaler.UnmarshalASTM(value, annotation.Attributes, config)
+		if err != nil {
+			return true, fmt.Errorf("%w: %w", errmsg.ErrLineParsingDataParsingError, err)
+		}
+		return true, nil
+	}
+	// The text unmarshaler gets the value without escape characters
+	if unmarshaler, ok := target.(encoding.TextUnmarshaler); ok && hasTextCodec(field.Type()) {
+		err = unmarshaler.UnmarshalText([]byte(filterStringEscapeChars(value, config.Delimiters.Escape)))
+		if err != nil {
+			return true, fmt.Errorf("%w: %w", errmsg.ErrLineParsingDataParsingError, err)
+		}
+		return true, nil
+	}
+	// Not a custom type
+	return false, nil
+}
+
+func marshalCustomField(field reflect.Value, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (result string, handled bool, err error) {
+	// Nil pointers are empty, otherwise the pointed value is checked
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "", false, nil
+		}
+		field = field.Elem()
+	}
+	for _, candidate := range marshalCandidates(field) {
+		if marshaler, ok := candidate.Interface().(astmmodels.AstmMarshaler); ok {
+			result, err = marshaler.MarshalASTM(annotation.Attributes, config)
+			return result, true, err
+		}
+	}
+	for _, candidate := range marshalCandidates(field) {
+		if marshaler, ok := candidate.Interface().(encoding.TextMarshaler); ok && hasTextCodec(field.Type()) {
+			// Text is escaped just like strings (by convertField)
+			text, err := marshaler.MarshalText()
+			if err != nil {
+				return "", true, err
+			}
+			return string(text), true, nil
+		}
+	}
+	// Not a custom type
+	return "", false, nil
+}
+
+func marshalCandidates(field reflect.Value) []reflect.Value {
+	// Check the value first, then the pointer for pointer receivers
+	candidates := []reflect.Value{field}
+	if field.CanAddr() {
+		candidates = append(candidates, field.Addr())
+	}
+	return candidates
+}
+
+func isAstmMarshaler(field reflect.Value) bool {
+	for _, candidate := range marshalCandidates(field) {
+		if _, ok := candidate.Interface().(astmmodels.AstmMarshaler); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isTextMarshaler(field reflect.Value) bool {
+	for _, candidate := range marshalCandidates(field) {
+		if _, ok := candidate.Interface().(encoding.TextMarshaler); ok && hasTextCodec(field.Type()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestParseLine_CustomCodecRecord(t *testing.T) {
+	// Arrange
+	input := "T|1|<H>|AB|<L>\\<LL>|x^<N>"
+	target := CustomCodecRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "H", target.Flag.Code)
+	assert.Equal(t, BloodGroup("AB"), target.BloodGroup)
+	assert.Equal(t, []ResultFlag{{Code: "L"}, {Code: "LL"}}, target.Flags)
+	assert.Equal(t, "N", target.Component.Code)
+}
+func TestParseLine_CustomCodecError(t *testing.T) {
+	// Arrange
+	input := "T|1|<H>|C"
+	target := CustomCodecRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	assert.ErrorContains(t, err, "invalid blood group")
+}
+func TestBuildLine_CustomCodecRecord(t *testing.T) {
+	// Arrange
+	source := CustomCodecRecord{
+		Flag:       ResultFlag{Code: "H"},
+		BloodGroup: "AB",
+		Flags:      []ResultFlag{{Code: "L"}, {Code: "LL"}},
+		Component:  ResultFlag{Code: "N"},
+	}
+	// Act
+	result, err := BuildLine(source, "T", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "T|1|<H>|group AB|<L>\\<LL>|^<N>", result)
+}
+func TestParseLine_DecimalRecord(t *testing.T) {
+	// Arrange
+	input := "D|1|1.50|-0.001|2\\3.0|x^.5"
+	target := DecimalRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("D"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "1.50", target.Value.String())
+	assert.Equal(t, 2, target.Value.Scale())
+	assert.Equal(t, 1.5, target.Value.Float64())
+	assert.NotNil(t, target.Pointer)
+	assert.Equal(t, -0.001, target.Pointer.Float64())
+	assert.Len(t, target.Array, 2)
+	assert.Equal(t, 0, target.Array[0].Cmp(astmmodels.NewDecimal(2, 0)))
+	assert.Equal(t, "3.0", target.Array[1].String())
+	assert.Equal(t, "0.5", target.Component.Round(1).String())
+}
+func TestParseLine_DecimalEmpty(t *testing.T) {
+	// Arrange
+	input := "D|1||"
+	target := DecimalRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("D"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, target.Value.IsEmpty())
+	assert.Nil(t, target.Pointer)
+}
+func TestParseLine_DecimalInvalid(t *testing.T) {
+	// Arrange
+	input := "D|1|1.5e3"
+	target := DecimalRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("D"), 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	assert.ErrorIs(t, err, errmsg.ErrDecimalInvalidFormat)
+}
+func TestBuildLine_DecimalRecord(t *testing.T) {
+	// Arrange
+	value, _ := astmmodels.ParseDecimal("1.50")
+	pointer, _ := astmmodels.ParseDecimal("+007")
+	source := DecimalRecord{
+		Value:     value,
+		Pointer:   &pointer,
+		Array:     []astmmodels.Decimal{astmmodels.NewDecimal(-5, 3), value.Round(1)},
+		Component: astmmodels.NewDecimal(125, 1).Round(0),
+	}
+	// Act
+	result, err := BuildLine(source, "D", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "D|1|1.50|+007|-0.005\\1.5|^13", result)
+}
+func TestHasCustomCodec_BuiltInTypes(t *testing.T) {
+	// Act
+	dateTime := hasCustomCodec(reflect.TypeOf(astmmodels.DateTime{}))
+	measurementValue := hasCustomCodec(reflect.TypeOf(astmmodels.MeasurementValue{}))
+	// Assert
+	assert.True(t, dateTime)
+	assert.True(t, measurementValue)
+	assert.Implements(t, (*astmmodels.AstmMarshaler)(nil), astmmodels.DateTime{})
+	assert.Implements(t, (*astmmodels.AstmUnmarshaler)(nil), &astmmodels.MeasurementValue{})
+}
+func TestDecimal_CmpAndRound(t *testing.T) {
+	// Arrange
+	small, _ := astmmodels.ParseDecimal("0.10")
+	large, _ := astmmodels.ParseDecimal("0.1000001")
+	negative, _ := astmmodels.ParseDecimal("-2.45")
+	// Act
+	fromFloat, err := astmmodels.NewDecimalFromFloat(3.14159, 3)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "3.142", fromFloat.String())
+	assert.Equal(t, -1, small.Cmp(large))
+	assert.Equal(t, 1, large.Cmp(small))
+	assert.Equal(t, 0, small.Cmp(astmmodels.NewDecimal(1, 1)))
+	assert.Equal(t, "-2.5", negative.Round(1).String())
+	assert.Equal(t, "-2.4500", negative.Round(4).String())
+	assert.Equal(t, "0.100", large.Round(3).String())
+}
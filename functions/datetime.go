@@ -0,0 +1,31 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/models"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"time"
+)
+
+func parseTime(value string, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (result time.Time, err error) {
+	// A time.Time is parsed like a DateTime, but an offset of the value is not kept
+	var dateTime astmmodels.DateTime
+	if err = dateTime.UnmarshalASTM(value, annotation.Attributes, config); err != nil {
+		return time.Time{}, err
+	}
+	return toFieldLocation(dateTime.Time, annotation, config), nil
+}
+
+func toFieldLocation(value time.Time, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) time.Time {
+	if _, exists := annotation.Attributes[constants.AttributeLongdate]; !exists && config.KeepShortDateTimeZone {
+		// Keep the short date time zone
+		return value.In(config.TimeLocation)
+	}
+	// Set the time to UTC
+	return value.UTC()
+}
+
+func formatTime(value time.Time, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (string, error) {
+	// A time.Time is formatted like a DateTime without precision (short or long date, or the layout attribute)
+	return astmmodels.DateTime{Time: value}.MarshalASTM(annotation.Attributes, config)
+}
@@ -0,0 +1,196 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/enums/precision"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseLine_TimePrecisions(t *testing.T) {
+	// Arrange
+	testCases := map[string]time.Time{
+		"2006":           time.Date(2006, 1, 1, 0, 0, 0, 0, config.TimeLocation),
+		"200603":         time.Date(2006, 3, 1, 0, 0, 0, 0, config.TimeLocation),
+		"20060306":       time.Date(2006, 3, 6, 0, 0, 0, 0, config.TimeLocation),
+		"2006030616":     time.Date(2006, 3, 6, 16, 0, 0, 0, config.TimeLocation),
+		"200603061644":   time.Date(2006, 3, 6, 16, 44, 0, 0, config.TimeLocation),
+		"20060306164429": time.Date(2006, 3, 6, 16, 44, 29, 0, config.TimeLocation),
+	}
+	for input, expected := range testCases {
+		target := TimeRecord{}
+		// Act
+		_, err := ParseLine("T|1|"+input, &target, createStructAnnotation("T"), 1, config)
+		// Assert
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected.UTC(), target.Time, input)
+	}
+}
+func TestParseLine_TimeOffsetOverridesTimeZone(t *testing.T) {
+	// Arrange
+	testCases := []string{
+		"20060306164429+0300",
+		"20060306164429+03:00",
+		"20060306164429+03",
+		"20060306134429Z",
+	}
+	for _, input := range testCases {
+		target := TimeRecord{}
+		// Act
+		_, err := ParseLine("T|1|"+input, &target, createStructAnnotation("T"), 1, config)
+		// Assert
+		assert.Nil(t, err, input)
+		assert.Equal(t, time.Date(2006, 3, 6, 13, 44, 29, 0, time.UTC), target.Time, input)
+	}
+}
+func TestParseLine_TimeFractionalSeconds(t *testing.T) {
+	// Arrange
+	input := "T|1|20060306164429.125-0100"
+	target := TimeRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2006, 3, 6, 17, 44, 29, 125000000, time.UTC), target.Time)
+}
+func TestParseLine_TimeInvalidFormats(t *testing.T) {
+	// Arrange
+	testCases := []string{
+		"20063",
+		"2006030616442",
+		"20060306.5",
+		"20060306164429.",
+		"20060306164429+1",
+		"20060306164429 CET",
+	}
+	for _, input := range testCases {
+		target := TimeRecord{}
+		// Act
+		_, err := ParseLine("T|1|"+input, &target, createStructAnnotation("T"), 1, config)
+		// Assert
+		assert.ErrorIs(t, err, errmsg.ErrLineParsingInvalidDateFormat, input)
+	}
+}
+func TestParseLine_TimeInvalidValue(t *testing.T) {
+	// Arrange
+	input := "T|1|20061306"
+	target := TimeRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+}
+func TestParseLine_TimeLayoutAttribute(t *testing.T) {
+	// Arrange
+	input := "T|1|06.03.2006 16:44:29"
+	target := LayoutTimeRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2006, 3, 6, 16, 44, 29, 0, config.TimeLocation).UTC(), target.Time)
+}
+func TestBuildLine_TimeLayoutAttribute(t *testing.T) {
+	// Arrange
+	source := LayoutTimeRecord{
+		Time: time.Date(2006, 3, 6, 15, 44, 29, 0, time.UTC),
+	}
+	// Act
+	result, err := BuildLine(source, "T", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "T|1|06.03.2006 16:44:29", result)
+}
+func TestParseAstmFieldAnnotationString_LayoutAttribute(t *testing.T) {
+	// Arrange
+	input := "3,layout:2006-01-02T15:04:05"
+	// Act
+	result, err := parseAstmFieldAnnotationString(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "2006-01-02T15:04:05", result.Attributes[constants.AttributeLayout])
+}
+func TestParseAstmFieldAnnotationString_LayoutAttributeWithComma(t *testing.T) {
+	// Arrange
+	input := "3,layout:20060102150405\\,000,longdate"
+	// Act
+	result, err := parseAstmFieldAnnotationString(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "20060102150405,000", result.Attributes[constants.AttributeLayout])
+	assert.Contains(t, result.Attributes, constants.AttributeLongdate)
+}
+func TestParseLine_CommaLayoutTime(t *testing.T) {
+	// Arrange
+	input := "T|1|20240315122030,250"
+	target := CommaLayoutTimeRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 15, 12, 20, 30, 250000000, config.TimeLocation), target.Time)
+}
+func TestBuildLine_CommaLayoutTime(t *testing.T) {
+	// Arrange
+	source := CommaLayoutTimeRecord{Time: time.Date(2024, 3, 15, 12, 20, 30, 250000000, config.TimeLocation)}
+	// Act
+	result, err := BuildLine(source, "T", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "T|1|20240315122030,250", result)
+}
+func TestParseLine_DateTimePrecision(t *testing.T) {
+	// Arrange
+	input := "T|1|202403|20240315122030+0100|2024031512\\20240315122030.25Z"
+	target := DateTimeRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, precision.Month, target.Value.Precision)
+	assert.False(t, target.Value.HasOffset)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, config.TimeLocation), target.Value.Time)
+	assert.Equal(t, precision.Second, target.Pointer.Precision)
+	assert.True(t, target.Pointer.HasOffset)
+	assert.Equal(t, time.Date(2024, 3, 15, 11, 20, 30, 0, time.UTC), target.Pointer.Time.UTC())
+	_, offset := target.Pointer.Time.Zone()
+	assert.Equal(t, 3600, offset)
+	assert.Len(t, target.Array, 2)
+	assert.Equal(t, precision.Hour, target.Array[0].Precision)
+	assert.Equal(t, precision.FractionalSecond, target.Array[1].Precision)
+}
+func TestBuildLine_DateTimeSamePrecision(t *testing.T) {
+	// Arrange
+	input := "T|1|202403|20240315122030+0100|2024031512\\20240315122030.25Z"
+	target := DateTimeRecord{}
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	assert.Nil(t, err)
+	// Act
+	result, err := BuildLine(target, "T", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, input, result)
+}
+func TestBuildLine_DateTimeFromPrecision(t *testing.T) {
+	// Arrange
+	source := DateTimeRecord{
+		Value: astmmodels.DateTime{
+			Time:      time.Date(2024, 3, 15, 11, 20, 30, 0, time.UTC),
+			Precision: precision.Minute,
+		},
+		Pointer: &astmmodels.DateTime{
+			Time:      time.Date(2024, 3, 15, 11, 20, 30, 0, time.FixedZone("", -2*3600)),
+			Precision: precision.Second,
+			HasOffset: true,
+		},
+		Array: []astmmodels.DateTime{{}, {Time: time.Date(2024, 3, 15, 11, 20, 30, 0, time.UTC)}},
+	}
+	// Act
+	result, err := BuildLine(source, "T", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "T|1|202403151220|20240315112030-0200|\\20240315", result)
+}
@@ -0,0 +1,21 @@
+package functions
+
+import (
+	"fmt"
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/models"
+	"strings"
+)
+
+func checkEnumValue(value string, annotation models.AstmFieldAnnotation, invalidValueErr error) error {
+	// Empty values are not checked (use required for mandatory fields)
+	allowedValues, exists := annotation.Attributes[constants.AttributeEnum]
+	if !exists || value == "" {
+		return nil
+	}
+	// The value must match one of the allowed values exactly
+	if !isInList(value, strings.Split(allowedValues, "|")) {
+		return fmt.Errorf("%w (%s)", invalidValueErr, allowedValues)
+	}
+	return nil
+}
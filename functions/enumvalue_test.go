@@ -0,0 +1,106 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseLine_EnumRecordValid(t *testing.T) {
+	// Arrange
+	input := "P|1|F|S\\R|N^x|2"
+	target := AllowedValuesRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("P"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "F", target.Gender)
+	assert.Equal(t, []string{"S", "R"}, target.Priority)
+	assert.Equal(t, "N", target.ActionCode)
+	assert.Equal(t, 2, target.Count)
+}
+func TestParseLine_EnumRecordEmpty(t *testing.T) {
+	// Arrange
+	input := "P|1||S\\"
+	target := AllowedValuesRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("P"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "", target.Gender)
+}
+func TestParseLine_EnumRecordInvalid(t *testing.T) {
+	// Arrange
+	input := "P|1|X|S\\Q"
+	target := AllowedValuesRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("P"), 1, config)
+	// Assert
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingInvalidEnumValue)
+	assert.Equal(t, 3, parseError.FieldPos)
+	assert.Equal(t, "X", parseError.Value)
+}
+func TestParseLine_EnumRecordInvalidLenient(t *testing.T) {
+	// Arrange
+	config.LenientParsing = true
+	input := "P|1|X|S\\Q|Z^x|3"
+	target := AllowedValuesRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("P"), 1, config)
+	// Assert
+	var parseErrors errmsg.ParseErrors
+	assert.ErrorAs(t, err, &parseErrors)
+	assert.Len(t, parseErrors, 4)
+	assert.Equal(t, "", target.Gender)
+	assert.Equal(t, []string{"S", ""}, target.Priority)
+	teardown()
+}
+func TestBuildLine_EnumRecordValid(t *testing.T) {
+	// Arrange
+	source := AllowedValuesRecord{Gender: "U", Priority: []string{"A"}, ActionCode: "C", Count: 1}
+	// Act
+	result, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|1|U|A|C|1", result)
+}
+func TestBuildLine_EnumRecordInvalid(t *testing.T) {
+	// Arrange
+	source := AllowedValuesRecord{Gender: "U", Priority: []string{"A", "X"}, Count: 1}
+	// Act
+	_, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineBuildingInvalidEnumValue)
+	assert.ErrorContains(t, err, `"X"`)
+}
+func TestBuildLine_EnumRecordZeroNumber(t *testing.T) {
+	// Arrange
+	source := AllowedValuesRecord{}
+	// Act
+	_, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineBuildingInvalidEnumValue)
+}
+func TestParseLine_EnumRecordEscaped(t *testing.T) {
+	// Arrange
+	input := "P|1|A&^B"
+	target := EscapedAllowedValuesRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("P"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "A^B", target.Code)
+}
+func TestBuildLine_EnumRecordEscaped(t *testing.T) {
+	// Arrange
+	config.EscapeOutputStrings = true
+	source := EscapedAllowedValuesRecord{Code: "A^B"}
+	// Act
+	result, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|1|A&^B", result)
+	teardown()
+}
@@ -0,0 +1,154 @@
+package functions
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"strconv"
+)
+
+func BuildFrames(records [][]byte, firstFrameNumber int) (frames [][]byte, err error) {
+	// Check for empty input
+	if len(records) == 0 {
+		return nil, errmsg.ErrFramingEmptyInput
+	}
+
+	frameNumber := firstFrameNumber % constants.FrameNumberModulo
+	for _, record := range records {
+		// Every record is terminated by a carriage return and starts in a new frame
+		text := append(append([]byte{}, record...), constants.CR)
+		// Split the record text into frames of the maximum allowed length
+		for start := 0; start < len(text); start += constants.MaxFrameTextLength {
+			end := start + constants.MaxFrameTextLength
+			// Intermediate frames are closed with ETB, the last frame of a record with ETX
+			terminator := constants.ETB
+			if end >= len(text) {
+				end = len(text)
+				terminator = constants.ETX
+			}
+			frames = append(frames, buildFrame(frameNumber, text[start:end], terminator))
+			frameNumber = (frameNumber + 1) % constants.FrameNumberModulo
+		}
+	}
+
+	// Return the frames and no error if everything went well
+	return frames, nil
+}
+
+func buildFrame(frameNumber int, text []byte, terminator byte) (frame []byte) {
+	// <STX><FN><text><ETB|ETX><C1><C2><CR><LF>
+	frame = make([]byte, 0, len(text)+7)
+	frame = append(frame, constants.STX)
+	frame = append(frame, []byte(strconv.Itoa(frameNumber))...)
+	frame = append(frame, text...)
+	frame = append(frame, terminator)
+	frame = append(frame, computeChecksum(frame[1:])...)
+	frame = append(frame, constants.CR, constants.LF)
+	return frame
+}
+
+func computeChecksum(input []byte) (checksum []byte) {
+	// The checksum is the modulo 256 sum of all characters from the frame number to the terminator
+	sum := 0
+	for _, b := range input {
+		sum += int(b)
+	}
+	return []byte(fmt.Sprintf("%02X", sum%256))
+}
+
+func ParseFrame(frame []byte) (frameNumber int, text []byte, isLast bool, err error) {
+	// The shortest possible frame is <STX><FN><ETX><C1><C2><CR><LF>
+	if len(frame) < 7 {
+		return 0, nil, false, errmsg.ErrFramingInvalidFrame
+	}
+	// Check the start and the trailer of the frame
+	if frame[0] != constants.STX || frame[len(frame)-2] != constants.CR || frame[len(frame)-1] != constants.LF {
+		return 0, nil, false, errmsg.ErrFramingInvalidFrame
+	}
+	// Check the frame terminator
+	terminatorIndex := len(frame) - 5
+	switch frame[terminatorIndex] {
+	case constants.ETX:
+		isLast = true
+	case constants.ETB:
+		isLast = false
+	default:
+		return 0, nil, false, errmsg.ErrFramingInvalidFrame
+	}
+	// Parse the frame number
+	if frame[1] < '0' || frame[1] >= '0'+byte(constants.FrameNumberModulo) {
+		return 0, nil, false, errmsg.ErrFramingInvalidFrame
+	}
+	frameNumber = int(frame[1] - '0')
+	// Validate the checksum (hex digits are accepted in both cases)
+	checksum := bytes.ToUpper(frame[terminatorIndex+1 : terminatorIndex+3])
+	if !bytes.Equal(checksum, computeChecksum(frame[1:terminatorIndex+1])) {
+		return 0, nil, false, errmsg.ErrFramingChecksumMismatch
+	}
+	// Return the text part of the frame
+	return frameNumber, frame[2:terminatorIndex], isLast, nil
+}
+
+func ParseFrames(frames [][]byte, firstFrameNumber int) (result []byte, err error) {
+	// Check for empty input
+	if len(frames) == 0 {
+		return nil, errmsg.ErrFramingEmptyInput
+	}
+
+	expectedFrameNumber := firstFrameNumber % constants.FrameNumberModulo
+	isLast := false
+	for i, frame := range frames {
+		frameNumber, text, last, err := ParseFrame(frame)
+		if err != nil {
+			return nil, fmt.Errorf("%w @frame %d", err, i+1)
+		}
+		// Frames have to follow each other without gaps or repetitions
+		if frameNumber != expectedFrameNumber {
+			return nil, fmt.Errorf("%w @frame %d", errmsg.ErrFramingFrameNumberMismatch, i+1)
+		}
+		expectedFrameNumber = (expectedFrameNumber + 1) % constants.FrameNumberModulo
+		// Reassemble the record texts (records keep their terminating carriage return)
+		result = append(result, text...)
+		isLast = last
+	}
+	// The last frame has to close the record with ETX
+	if !isLast {
+		return nil, errmsg.ErrFramingIncompleteMessage
+	}
+
+	// Return the reassembled records and no error if everything went well
+	return result, nil
+}
+
+func SliceFrames(input []byte) (frames [][]byte, err error) {
+	// Check for empty input
+	if len(input) == 0 {
+		return nil, errmsg.ErrFramingEmptyInput
+	}
+
+	for i := 0; i < len(input); i++ {
+		// Anything outside of frames (ENQ, ACK, EOT, line noise) is skipped
+		if input[i] != constants.STX {
+			continue
+		}
+		// Find the terminator of the frame
+		end := i + 1
+		for end < len(input) && input[end] != constants.ETX && input[end] != constants.ETB {
+			end++
+		}
+		// The terminator has to be followed by the checksum and the trailer
+		if end+4 >= len(input) {
+			return nil, errmsg.ErrFramingInvalidFrame
+		}
+		frames = append(frames, input[i:end+5])
+		i = end + 4
+	}
+	// At least one frame has to be found
+	if len(frames) == 0 {
+		return nil, errmsg.ErrFramingInvalidFrame
+	}
+
+	// Return the frames and no error if everything went well
+	return frames, nil
+}
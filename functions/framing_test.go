@@ -0,0 +1,153 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestBuildFrames_SingleRecord(t *testing.T) {
+	// Arrange
+	input := [][]byte{[]byte("H|\\^&")}
+	// Act
+	frames, err := BuildFrames(input, 1)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, frames, 1)
+	assert.Equal(t, "\u00021H|\\^&\r\u0003E5\r\n", string(frames[0]))
+}
+func TestBuildFrames_MultipleRecords(t *testing.T) {
+	// Arrange
+	input := [][]byte{[]byte("H|\\^&"), []byte("L|1|N")}
+	// Act
+	frames, err := BuildFrames(input, 1)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, frames, 2)
+	assert.Equal(t, "\u00021H|\\^&\r\u0003E5\r\n", string(frames[0]))
+	assert.Equal(t, "\u00022L|1|N\r\u000305\r\n", string(frames[1]))
+}
+func TestBuildFrames_LongRecord(t *testing.T) {
+	// Arrange
+	input := [][]byte{[]byte("R|1|" + strings.Repeat("x", 500))}
+	// Act
+	frames, err := BuildFrames(input, 1)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, frames, 3)
+	assert.Len(t, frames[0], 247)
+	assert.Equal(t, byte('1'), frames[0][1])
+	assert.Equal(t, byte(0x17), frames[0][242])
+	assert.Equal(t, byte('2'), frames[1][1])
+	assert.Equal(t, byte(0x17), frames[1][242])
+	assert.Equal(t, byte('3'), frames[2][1])
+	assert.Equal(t, byte(0x03), frames[2][len(frames[2])-5])
+}
+func TestBuildFrames_FrameNumberWrapAround(t *testing.T) {
+	// Arrange
+	input := make([][]byte, 9)
+	for i := range input {
+		input[i] = []byte("C|1")
+	}
+	// Act
+	frames, err := BuildFrames(input, 1)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, frames, 9)
+	assert.Equal(t, byte('7'), frames[6][1])
+	assert.Equal(t, byte('0'), frames[7][1])
+	assert.Equal(t, byte('1'), frames[8][1])
+}
+func TestBuildFrames_EmptyInput(t *testing.T) {
+	// Act
+	_, err := BuildFrames(nil, 1)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrFramingEmptyInput)
+}
+
+func TestParseFrame_LastFrame(t *testing.T) {
+	// Arrange
+	input := []byte("\u00021H|\\^&\r\u0003E5\r\n")
+	// Act
+	frameNumber, text, isLast, err := ParseFrame(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 1, frameNumber)
+	assert.Equal(t, "H|\\^&\r", string(text))
+	assert.True(t, isLast)
+}
+func TestParseFrame_LowercaseChecksum(t *testing.T) {
+	// Arrange
+	input := []byte("\u00021H|\\^&\r\u0003e5\r\n")
+	// Act
+	_, _, _, err := ParseFrame(input)
+	// Assert
+	assert.Nil(t, err)
+}
+func TestParseFrame_ChecksumMismatch(t *testing.T) {
+	// Arrange
+	input := []byte("\u00021H|\\^&\r\u0003E6\r\n")
+	// Act
+	_, _, _, err := ParseFrame(input)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrFramingChecksumMismatch)
+}
+func TestParseFrame_InvalidFrame(t *testing.T) {
+	// Arrange
+	input := []byte("1H|\\^&\r\u0003E5\r\n")
+	// Act
+	_, _, _, err := ParseFrame(input)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrFramingInvalidFrame)
+}
+
+func TestParseFrames_RoundTrip(t *testing.T) {
+	// Arrange
+	input := [][]byte{[]byte("H|\\^&"), []byte("R|1|" + strings.Repeat("x", 500)), []byte("L|1|N")}
+	frames, _ := BuildFrames(input, 1)
+	// Act
+	result, err := ParseFrames(frames, 1)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "H|\\^&\rR|1|"+strings.Repeat("x", 500)+"\rL|1|N\r", string(result))
+}
+func TestParseFrames_FrameNumberMismatch(t *testing.T) {
+	// Arrange
+	input := [][]byte{
+		[]byte("\u00021H|\\^&\r\u0003E5\r\n"),
+		[]byte("\u00021H|\\^&\r\u0003E5\r\n"),
+	}
+	// Act
+	_, err := ParseFrames(input, 1)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrFramingFrameNumberMismatch)
+}
+func TestParseFrames_IncompleteMessage(t *testing.T) {
+	// Arrange
+	frames, _ := BuildFrames([][]byte{[]byte(strings.Repeat("x", 300))}, 1)
+	// Act
+	_, err := ParseFrames(frames[:1], 1)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrFramingIncompleteMessage)
+}
+
+func TestSliceFrames_WithControlCharacters(t *testing.T) {
+	// Arrange
+	input := []byte("\u0005\u00021H|\\^&\r\u0003E5\r\n\u0006\u00022L|1|N\r\u000305\r\n\u0006\u0004")
+	// Act
+	frames, err := SliceFrames(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, frames, 2)
+	assert.Equal(t, "\u00021H|\\^&\r\u0003E5\r\n", string(frames[0]))
+	assert.Equal(t, "\u00022L|1|N\r\u000305\r\n", string(frames[1]))
+}
+func TestSliceFrames_TruncatedFrame(t *testing.T) {
+	// Arrange
+	input := []byte("\u00021H|\\^&\r\u0003E5")
+	// Act
+	_, err := SliceFrames(input)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrFramingInvalidFrame)
+}
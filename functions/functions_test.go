@@ -1,6 +1,7 @@
 package functions
 
 import (
+	"errors"
 	"github.com/blutspende/go-astm/v3/models"
 	"github.com/blutspende/go-astm/v3/models/astmmodels"
 	"testing"
@@ -61,10 +62,13 @@ type Substructure struct {
 	FirstComponent  string `astm:"1"`
 	SecondComponent string `astm:"2"`
 }
-type IllegalComponentArray struct {
+type ComponentArray struct {
 	ComponentArray []string `astm:"3.1"`
 }
 type IllegalComponentSubstructure struct {
+	ComponentSubstructure Substructure `astm:"3.1.1"`
+}
+type ComponentSubstructure struct {
 	ComponentSubstructure Substructure `astm:"3.1"`
 }
 type SubstructuredLine struct {
@@ -126,6 +130,26 @@ type MultitypePointerRecord struct {
 	Float64 *float64   `astm:"6"`
 	Date    *time.Time `astm:"7"`
 }
+type ScalarKindsRecord struct {
+	Int8     int8     `astm:"3"`
+	Int16    int16    `astm:"4"`
+	Int32    int32    `astm:"5"`
+	Int64    int64    `astm:"6"`
+	Uint     uint     `astm:"7"`
+	Uint8    uint8    `astm:"8"`
+	Uint16   uint16   `astm:"9"`
+	Uint32   uint32   `astm:"10"`
+	Uint64   uint64   `astm:"11"`
+	Bool     bool     `astm:"12"`
+	NamedInt NamedInt `astm:"13"`
+}
+type NamedInt int
+type PointerComponentRecord struct {
+	First  *int    `astm:"3.1"`
+	Second *int    `astm:"3.2"`
+	Third  *string `astm:"3.3"`
+	Flag   *bool   `astm:"4"`
+}
 type ComponentedRecord struct {
 	First       string `astm:"3"`
 	SecondComp1 string `astm:"4.1"`
@@ -214,6 +238,17 @@ type SparseSubstructureRecord struct {
 type TimeRecord struct {
 	Time time.Time `astm:"3,longdate"`
 }
+type LayoutTimeRecord struct {
+	Time time.Time `astm:"3,longdate,layout:02.01.2006 15:04:05"`
+}
+type CommaLayoutTimeRecord struct {
+	Time time.Time `astm:"3,layout:20060102150405\\,000"`
+}
+type DateTimeRecord struct {
+	Value   astmmodels.DateTime   `astm:"3"`
+	Pointer *astmmodels.DateTime  `astm:"4"`
+	Array   []astmmodels.DateTime `astm:"5"`
+}
 type ShortDateRecord struct {
 	Time time.Time `astm:"3"`
 }
@@ -244,6 +279,99 @@ type MissingAnnotationRecord struct {
 	Missing string
 	Field4  string `astm:"4"`
 }
+type IntComponentRecord struct {
+	First  string `astm:"3.1"`
+	Second int    `astm:"3.2"`
+}
+type IntSubstructure struct {
+	First  string `astm:"1"`
+	Second int    `astm:"2"`
+}
+type IntSubstructureArrayRecord struct {
+	Array []IntSubstructure `astm:"3"`
+}
+type RawFieldsRecord struct {
+	First  string              `astm:"3"`
+	Second string              `astm:"4.1"`
+	Array  []SubstructureField `astm:"5"`
+	Raw    astmmodels.RawFields
+}
+type RawFieldsSubComponentRecord struct {
+	Code     string `astm:"3.1"`
+	Dilution string `astm:"3.2.1"`
+	Raw      astmmodels.RawFields
+}
+type CustomCodecRecord struct {
+	Flag       ResultFlag   `astm:"3"`
+	BloodGroup BloodGroup   `astm:"4"`
+	Flags      []ResultFlag `astm:"5"`
+	Component  ResultFlag   `astm:"6.2"`
+}
+type DecimalRecord struct {
+	Value     astmmodels.Decimal   `astm:"3"`
+	Pointer   *astmmodels.Decimal  `astm:"4"`
+	Array     []astmmodels.Decimal `astm:"5"`
+	Component astmmodels.Decimal   `astm:"6.2"`
+}
+type MeasurementValueRecord struct {
+	Value     astmmodels.MeasurementValue   `astm:"3"`
+	Pointer   *astmmodels.MeasurementValue  `astm:"4"`
+	Array     []astmmodels.MeasurementValue `astm:"5"`
+	Component astmmodels.MeasurementValue   `astm:"6.2"`
+}
+type RepeatedComponentRecord struct {
+	Names       []string `astm:"3.2"`
+	Lots        []string `astm:"3.3"`
+	First       string   `astm:"4.1"`
+	Expirations []int    `astm:"4.2"`
+}
+type SubComponentRecord struct {
+	Code         string `astm:"3.1"`
+	Dilution     string `astm:"3.2.1"`
+	DilutionUnit string `astm:"3.2.2"`
+	Flags        []int  `astm:"4.1.2"`
+}
+type NestedSubstructure struct {
+	Code     string       `astm:"1"`
+	Dilution Substructure `astm:"2"`
+	Flags    []string     `astm:"3"`
+	Unit     string       `astm:"4.2"`
+}
+type NestedSubstructureRecord struct {
+	Field      NestedSubstructure   `astm:"3"`
+	Array      []NestedSubstructure `astm:"4"`
+	Component  Substructure         `astm:"5.2"`
+	Components []Substructure       `astm:"6.2"`
+}
+type TooDeepSubstructure struct {
+	Nested NestedSubstructure `astm:"1"`
+}
+type TooDeepSubstructureRecord struct {
+	Field TooDeepSubstructure `astm:"3"`
+}
+type DefaultRecord struct {
+	ProcessingID string   `astm:"3,default:P"`
+	Count        int      `astm:"4,default:1"`
+	Pointer      *string  `astm:"5,default:N"`
+	Version      string   `astm:"6.2,default:LIS2-A2"`
+	Time         string   `astm:"7,default:12:00"`
+	Codes        []string `astm:"8,default:X"`
+}
+type MaxLengthRecord struct {
+	Name   string   `astm:"3,maxlen:5"`
+	Codes  []string `astm:"4,maxlen:2"`
+	First  string   `astm:"5.1,maxlen:3"`
+	Number int      `astm:"6,maxlen:2"`
+}
+type AllowedValuesRecord struct {
+	Gender     string   `astm:"3,enum:M|F|U"`
+	Priority   []string `astm:"4,enum:S|A|R"`
+	ActionCode string   `astm:"5.1,enum:C|A|N"`
+	Count      int      `astm:"6,enum:1|2"`
+}
+type EscapedAllowedValuesRecord struct {
+	Code string `astm:"3,enum:A^B|C"`
+}
 type InvalidAttributeValueRecord struct {
 	First float64 `astm:"3,length:one"`
 }
@@ -303,3 +431,33 @@ type SubnameOptionalMessage struct {
 	Record1 SubnameRecordType1 `astm:"R,subname:FIRST,optional"`
 	Record2 SubnameRecordType2 `astm:"R,subname:SECOND"`
 }
+
+// Custom codec types
+type ResultFlag struct {
+	Code string
+}
+
+func (f ResultFlag) MarshalASTM(attributes map[string]string, config *astmmodels.Configuration) (string, error) {
+	return "<" + f.Code + ">", nil
+}
+func (f *ResultFlag) UnmarshalASTM(value string, attributes map[string]string, config *astmmodels.Configuration) error {
+	if len(value) < 2 || value[0] != '<' || value[len(value)-1] != '>' {
+		return errors.New("invalid result flag")
+	}
+	f.Code = value[1 : len(value)-1]
+	return nil
+}
+
+type BloodGroup string
+
+func (b BloodGroup) MarshalText() ([]byte, error) {
+	return []byte("group " + string(b)), nil
+}
+func (b *BloodGroup) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "A", "B", "AB", "0":
+		*b = BloodGroup(text)
+		return nil
+	}
+	return errors.New("invalid blood group")
+}
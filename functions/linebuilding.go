@@ -2,6 +2,7 @@ package functions
 
 import (
 	"errors"
+	"fmt"
 	"github.com/blutspende/go-astm/v3/constants"
 	notationconst "github.com/blutspende/go-astm/v3/enums/notation"
 	"github.com/blutspende/go-astm/v3/errmsg"
@@ -16,6 +17,10 @@ import (
 )
 
 func BuildLine(sourceStruct interface{}, lineTypeName string, sequenceNumber int, config *astmmodels.Configuration) (result string, err error) {
+	return buildLine(sourceStruct, lineTypeName, sequenceNumber, config, nil)
+}
+
+func buildLine(sourceStruct interface{}, lineTypeName string, sequenceNumber int, config *astmmodels.Configuration, report *astmmodels.MarshalReport) (result string, err error) {
 	// Process the target structure
 	sourceTypes, sourceValues, sourceTypesLength, err := ProcessStructReflection(sourceStruct)
 	if err != nil {
@@ -58,21 +63,36 @@ func BuildLine(sourceStruct interface{}, lineTypeName string, sequenceNumber int
 			return "", errmsg.ErrLineBuildingReservedFieldPosReference
 		}
 
+		// Values truncated while building the field get its position
+		truncatedFrom := truncatedValueCount(report)
+
 		fieldValueString := ""
-		// If the field is an array, iterate over its elements and use the Repeat delimiter
-		if sourceFieldAnnotation.IsArray {
+		if sourceFieldAnnotation.IsComponent {
+			if slices.Contains(processedComponentFields, sourceFieldAnnotation.FieldPos) {
+				// If the field is already processed, skip it
+				continue
+			}
+			// Build the field from all the components (and component arrays) anywhere in the struct
+			fieldValueString, err = buildComponentField(sourceFieldAnnotation.FieldPos, sourceTypes, sourceValues, config, report)
+			if err != nil {
+				return "", err
+			}
+			// Mark the field as processed
+			processedComponentFields = append(processedComponentFields, sourceFieldAnnotation.FieldPos)
+		} else if sourceFieldAnnotation.IsArray {
+			// If the field is an array, iterate over its elements and use the Repeat delimiter
 			for j := 0; j < sourceValues[i].Len(); j++ {
 				elementValue := sourceValues[i].Index(j)
 				convertedValue := ""
 				if sourceFieldAnnotation.IsSubstructure {
 					// If the field is a substructure use buildSubstructure to process it
-					convertedValue, err = buildSubstructure(elementValue.Interface(), config)
+					convertedValue, err = buildSubstructure(elementValue.Interface(), 0, config, report)
 					if err != nil {
 						return "", err
 					}
 				} else {
 					// Simple field, convert it directly
-					convertedValue, err = convertField(elementValue, sourceFieldAnnotation, config)
+					convertedValue, err = convertField(elementValue, sourceFieldAnnotation, config, report)
 					if err != nil {
 						return "", err
 					}
@@ -82,73 +102,105 @@ func BuildLine(sourceStruct interface{}, lineTypeName string, sequenceNumber int
 					fieldValueString += config.Delimiters.Repeat
 				}
 			}
-		} else if sourceFieldAnnotation.IsComponent {
-			if slices.Contains(processedComponentFields, sourceFieldAnnotation.FieldPos) {
-				// If the field is already processed, skip it
-				continue
-			}
-			// Create a map to store the component values indexed by ComponentPos
-			componentMap := make(map[int]string)
-			// Iterate over the whole inputFields of the targetStruct struct to find the components anywhere
-			for j := 0; j < sourceTypesLength; j++ {
-				// Parse the targetStruct field targetFieldAnnotation
-				currentFieldAnnotation, err := ParseAstmFieldAnnotation(sourceTypes[j])
-				if err != nil {
-					if errors.Is(err, errmsg.ErrAnnotationParsingMissingAstmAnnotation) {
-						// If the annotation is missing, skip this field
-						continue
-					} else {
-						return "", err
-					}
-				}
-				// If the field number is the same as the sourceFieldAnnotation, process it
-				if currentFieldAnnotation.FieldPos == sourceFieldAnnotation.FieldPos {
-					// Convert current component
-					componentValue, err := convertField(sourceValues[j], currentFieldAnnotation, config)
-					if err != nil {
-						return "", err
-					}
-					// Store the value in the component map
-					componentMap[currentFieldAnnotation.ComponentPos] = componentValue
-				}
-			}
-			// Construct the result into the fieldValueString
-			fieldValueString = constructResult(componentMap, config.Delimiters.Component, config.Notation)
-			// Mark the field as processed
-			processedComponentFields = append(processedComponentFields, sourceFieldAnnotation.FieldPos)
 		} else if sourceFieldAnnotation.IsSubstructure {
 			// If the field is a substructure use buildSubstructure to process it
-			fieldValueString, err = buildSubstructure(sourceValues[i].Interface(), config)
+			fieldValueString, err = buildSubstructure(sourceValues[i].Interface(), 0, config, report)
 			if err != nil {
 				return "", err
 			}
 		} else {
 			// If the field is not an array, convert it directly
-			fieldValueString, err = convertField(sourceValues[i], sourceFieldAnnotation, config)
+			fieldValueString, err = convertField(sourceValues[i], sourceFieldAnnotation, config, report)
 			if err != nil {
 				return "", err
 			}
 		}
 
+		addReportRecordPosition(report, truncatedFrom, lineTypeName, sequenceNumber, sourceFieldAnnotation.FieldPos)
+
 		// Store the field value in the map using FieldPos as the key
 		fieldMap[sourceFieldAnnotation.FieldPos] = fieldValueString
 	}
 
+	// Merge the preserved raw fields back at their original positions
+	mergeRawFields(fieldMap, sourceTypes, sourceValues, config)
+
 	// Construct the result string based on the field map
 	result = constructResult(fieldMap, config.Delimiters.Field, config.Notation)
 
 	return result, nil
 }
 
-func buildSubstructure(sourceStruct interface{}, config *astmmodels.Configuration) (result string, err error) {
+func buildComponentField(fieldPos int, sourceTypes []reflect.StructField, sourceValues []reflect.Value, config *astmmodels.Configuration, report *astmmodels.MarshalReport) (result string, err error) {
+	// Collect the values by repeat, component and sub-component (sub-component 0 is the whole component)
+	repeats := make([]map[int]map[int]string, 0)
+	for i, sourceType := range sourceTypes {
+		// Parse the annotation and skip the fields of other positions
+		annotation, err := ParseAstmFieldAnnotation(sourceType)
+		if err != nil {
+			if errors.Is(err, errmsg.ErrAnnotationParsingMissingAstmAnnotation) {
+				continue
+			} else {
+				return "", err
+			}
+		}
+		if annotation.FieldPos != fieldPos {
+			continue
+		}
+		// A component array gives the component of each repeat, a single component belongs to the first repeat
+		values := []reflect.Value{sourceValues[i]}
+		if annotation.IsArray {
+			values = make([]reflect.Value, sourceValues[i].Len())
+			for j := range values {
+				values[j] = sourceValues[i].Index(j)
+			}
+		}
+		for j, value := range values {
+			convertedValue := ""
+			if annotation.IsSubstructure {
+				// The fields of a component substructure are the sub-components
+				convertedValue, err = buildSubstructure(value.Interface(), 1, config, report)
+			} else {
+				convertedValue, err = convertField(value, annotation, config, report)
+			}
+			if err != nil {
+				return "", err
+			}
+			for len(repeats) <= j {
+				repeats = append(repeats, make(map[int]map[int]string))
+			}
+			if repeats[j][annotation.ComponentPos] == nil {
+				repeats[j][annotation.ComponentPos] = make(map[int]string)
+			}
+			repeats[j][annotation.ComponentPos][annotation.SubComponentPos] = convertedValue
+		}
+	}
+	// Construct the components from the sub-components and the repeats from the components
+	repeatValues := make([]string, len(repeats))
+	for j, repeat := range repeats {
+		componentMap := make(map[int]string)
+		for componentPos, subComponentMap := range repeat {
+			if value, exists := subComponentMap[0]; exists {
+				componentMap[componentPos] = value
+			} else {
+				componentMap[componentPos] = constructSubComponents(subComponentMap, config)
+			}
+		}
+		repeatValues[j] = constructResult(componentMap, config.Delimiters.Component, config.Notation)
+	}
+	return strings.Join(repeatValues, config.Delimiters.Repeat), nil
+}
+
+func buildSubstructure(sourceStruct interface{}, depth int, config *astmmodels.Configuration, report *astmmodels.MarshalReport) (result string, err error) {
 	// Process the target structure
 	sourceTypes, sourceValues, sourceTypesLength, err := ProcessStructReflection(sourceStruct)
 	if err != nil {
 		return "", err
 	}
 
-	// Create a map to store component values indexed by FieldPos
+	// Create maps to store component values indexed by FieldPos (and the sub-components of component annotations)
 	componentMap := make(map[int]string)
+	subComponentMaps := make(map[int]map[int]string)
 
 	// Iterate over the inputFields of the targetStruct struct
 	for i := 0; i < sourceTypesLength; i++ {
@@ -162,16 +214,51 @@ func buildSubstructure(sourceStruct interface{}, config *astmmodels.Configuratio
 				return "", err
 			}
 		}
-		// Convert the component directly
-		componentValueString, err := convertField(sourceValues[i], sourceFieldAnnotation, config)
+		// Nested values need the deeper delimiter levels (sub-components are the deepest)
+		if nestingLevels(sourceFieldAnnotation) > constants.MaxSubstructureDepth-depth {
+			return "", errmsg.ErrAnnotationParsingSubstructureTooDeep
+		}
+		componentValueString := ""
+		if sourceFieldAnnotation.IsSubstructure {
+			// Nested substructure: its fields are the sub-components
+			componentValueString, err = buildSubstructure(sourceValues[i].Interface(), depth+1, config, report)
+		} else if sourceFieldAnnotation.IsArray {
+			// Array: the elements are the sub-components
+			subComponentMap := make(map[int]string)
+			for j := 0; j < sourceValues[i].Len() && err == nil; j++ {
+				subComponentMap[j+1], err = convertField(sourceValues[i].Index(j), sourceFieldAnnotation, config, report)
+			}
+			componentValueString = constructSubComponents(subComponentMap, config)
+		} else if sourceFieldAnnotation.IsComponent {
+			// Component annotation: a single sub-component, joined after all fields are processed
+			subComponentValueString, err := convertField(sourceValues[i], sourceFieldAnnotation, config, report)
+			if err != nil {
+				return "", err
+			}
+			if subComponentMaps[sourceFieldAnnotation.FieldPos] == nil {
+				subComponentMaps[sourceFieldAnnotation.FieldPos] = make(map[int]string)
+			}
+			subComponentMaps[sourceFieldAnnotation.FieldPos][sourceFieldAnnotation.ComponentPos] = subComponentValueString
+			continue
+		} else {
+			// Convert the component directly
+			componentValueString, err = convertField(sourceValues[i], sourceFieldAnnotation, config, report)
+		}
 		if err != nil {
 			return "", err
 		}
 		// Store the component value in the map using FieldPos as the key
 		componentMap[sourceFieldAnnotation.FieldPos] = componentValueString
 	}
+	// Join the sub-components of the component annotations
+	for fieldPos, subComponentMap := range subComponentMaps {
+		componentMap[fieldPos] = constructSubComponents(subComponentMap, config)
+	}
 
-	// Construct the result string
+	// Construct the result string (the substructure of a component contains sub-components)
+	if depth > 0 {
+		return constructSubComponents(componentMap, config), nil
+	}
 	result = constructResult(componentMap, config.Delimiters.Component, config.Notation)
 
 	// Return result with no error
@@ -203,7 +290,61 @@ func constructResult(fieldMap map[int]string, delimiter string, notation string)
 	return result
 }
 
-func convertField(field reflect.Value, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (result string, err error) {
+func constructSubComponents(subComponentMap map[int]string, config *astmmodels.Configuration) string {
+	// Trailing empty sub-components are dropped, a trailing escape character would escape the next delimiter
+	return constructResult(subComponentMap, config.Delimiters.Escape, notationconst.Short)
+}
+
+func convertField(field reflect.Value, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration, report *astmmodels.MarshalReport) (result string, err error) {
+	// Zero values (and nil pointers) are replaced by the default value (if any), otherwise the value is converted
+	defaultValue, isDefault := annotation.Attributes[constants.AttributeDefault]
+	isDefault = isDefault && field.IsZero()
+	if isDefault {
+		result = defaultValue
+	} else {
+		result, err = convertValue(field, annotation, config)
+		if err != nil {
+			return "", err
+		}
+	}
+	// Check the allowed values and apply the maximum length (if any)
+	if err = checkEnumValue(result, annotation, errmsg.ErrLineBuildingInvalidEnumValue); err != nil {
+		return "", fmt.Errorf("%w: %q", err, result)
+	}
+	result, err = applyMaxLength(result, annotation, config, report)
+	if err != nil {
+		return "", err
+	}
+	// Text values (and the default values) are escaped after the checks (the maximum length is measured without escape characters)
+	if config.EscapeOutputStrings && (isDefault || isTextField(field)) {
+		result = buildStringEscapeChars(result, config)
+	}
+	return result, nil
+}
+
+func isTextField(field reflect.Value) bool {
+	// Nil pointers are empty, otherwise the pointed value is checked
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return false
+		}
+		field = field.Elem()
+	}
+	// Custom types escape themselves, text marshalers are escaped like strings
+	if isAstmMarshaler(field) {
+		return false
+	}
+	if isTextMarshaler(field) {
+		return true
+	}
+	return field.Kind() == reflect.String
+}
+
+func convertValue(field reflect.Value, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (result string, err error) {
+	// Custom types format themselves
+	if result, handled, err := marshalCustomField(field, annotation, config); handled {
+		return result, err
+	}
 	// Check if the field is a pointer, nil returns empty, otherwise dereference it
 	if field.Kind() == reflect.Ptr {
 		if field.IsNil() {
@@ -215,17 +356,22 @@ func convertField(field reflect.Value, annotation models.AstmFieldAnnotation, co
 	switch field.Kind() {
 	case reflect.String:
 		if field.Type().ConvertibleTo(reflect.TypeOf("")) {
-			if config.EscapeOutputStrings {
-				result = buildStringEscapeChars(field.String(), config)
-			} else {
-				result = field.String()
-			}
+			result = field.String()
 		} else {
 			return "", errmsg.ErrLineBuildingUsupportedDataType
 		}
 		return result, nil
-	case reflect.Int:
-		result = strconv.Itoa(int(field.Int()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result = strconv.FormatInt(field.Int(), 10)
+		return result, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result = strconv.FormatUint(field.Uint(), 10)
+		return result, nil
+	case reflect.Bool:
+		result = "N"
+		if field.Bool() {
+			result = "Y"
+		}
 		return result, nil
 	case reflect.Float32, reflect.Float64:
 		precision := config.DefaultDecimalPrecision
@@ -245,26 +391,13 @@ func convertField(field reflect.Value, annotation models.AstmFieldAnnotation, co
 	case reflect.Struct:
 		// Check for time.Time type (it reflects as a Struct)
 		if field.Type() == reflect.TypeOf(time.Time{}) {
-			timeFormat := "20060102"
-			if _, exists := annotation.Attributes[constants.AttributeLongdate]; exists {
-				timeFormat = "20060102150405"
-			}
 			// Check if the field is a time.Time
 			timeValue, ok := field.Interface().(time.Time)
 			if !ok {
 				return "", errmsg.ErrLineBuildingInvalidDateFormat
 			}
-			// Return empty if the time is zero
-			if timeValue.IsZero() {
-				return "", nil
-			}
-			// Convert the time to the config's timezone
-			timeInLocation := timeValue.In(config.TimeLocation)
-			// Format the date as a string
-			result = timeInLocation.Format(timeFormat)
-			return result, nil
-		} else {
-			// Note: option to handle other struct types here
+			// Format the date as a string (zero time is empty)
+			return formatTime(timeValue, annotation, config)
 		}
 	}
 	// Return error if no type match was found (each successful conversion returns with nil)
@@ -272,16 +405,5 @@ func convertField(field reflect.Value, annotation models.AstmFieldAnnotation, co
 }
 
 func buildStringEscapeChars(input string, config *astmmodels.Configuration) string {
-	var builder strings.Builder
-	inputRunes := []rune(input)
-	for i := 0; i < len(inputRunes); i++ {
-		if inputRunes[i] == rune(config.Delimiters.Field[0]) ||
-			inputRunes[i] == rune(config.Delimiters.Repeat[0]) ||
-			inputRunes[i] == rune(config.Delimiters.Component[0]) ||
-			inputRunes[i] == rune(config.Delimiters.Escape[0]) {
-			builder.WriteRune(rune(config.Delimiters.Escape[0]))
-		}
-		builder.WriteRune(inputRunes[i])
-	}
-	return builder.String()
+	return config.Delimiters.EscapeValue(input)
 }
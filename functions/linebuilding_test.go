@@ -258,6 +258,21 @@ func TestBuildLine_HeaderRecordCustomDelimiters(t *testing.T) {
 	// Teardown
 	teardown()
 }
+func TestBuildLine_HeaderDoubledRepeatDelimiter(t *testing.T) {
+	// Arrange
+	source := HeaderDelimiterChange{
+		First: "first",
+		Array: []string{"second1", "second2"},
+	}
+	config.Delimiters.Repeat = "\\\\"
+	// Act
+	result, err := BuildLine(source, "H", 0, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "H|\\\\^&|first|second1\\\\second2|^", result)
+	// Teardown
+	teardown()
+}
 func TestBuildLine_HeaderDelimiterChange(t *testing.T) {
 	// Arrange
 	source := HeaderDelimiterChange{
@@ -607,3 +622,124 @@ func TestBuildLine_EscapedCharsNoEscape(t *testing.T) {
 	// Teardown
 	teardown()
 }
+
+func TestBuildLine_ScalarKindsRecord(t *testing.T) {
+	// Arrange
+	source := ScalarKindsRecord{
+		Int8: -8, Int16: -16, Int32: -32, Int64: -64,
+		Uint: 1, Uint8: 8, Uint16: 16, Uint32: 32, Uint64: 64,
+		Bool: true, NamedInt: 42,
+	}
+	// Act
+	result, err := BuildLine(source, "T", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "T|1|-8|-16|-32|-64|1|8|16|32|64|Y|42", result)
+}
+func TestBuildLine_RepeatedComponentRecord(t *testing.T) {
+	// Arrange
+	source := RepeatedComponentRecord{
+		Names:       []string{"Diluent 1", "Diluent 2", "Diluent 3"},
+		Lots:        []string{"05761.03", "", "05762.01"},
+		First:       "x",
+		Expirations: []int{20240131},
+	}
+	// Act
+	result, err := BuildLine(source, "C", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "C|1|^Diluent 1^05761.03\\^Diluent 2^\\^Diluent 3^05762.01|x^20240131", result)
+}
+func TestBuildLine_SubComponentRecord(t *testing.T) {
+	// Arrange
+	source := SubComponentRecord{
+		Code:         "DIL",
+		Dilution:     "1",
+		DilutionUnit: "10",
+		Flags:        []int{2, 3},
+	}
+	// Act
+	result, err := BuildLine(source, "S", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "S|1|DIL^1&10|&2\\&3", result)
+}
+func TestBuildLine_NestedSubstructureRecord(t *testing.T) {
+	// Arrange
+	source := NestedSubstructureRecord{
+		Field: NestedSubstructure{
+			Code:     "DIL",
+			Dilution: Substructure{FirstComponent: "1", SecondComponent: "10"},
+			Flags:    []string{"a", "b"},
+			Unit:     "mg",
+		},
+		Array:      []NestedSubstructure{{Code: "X"}},
+		Component:  Substructure{FirstComponent: "p", SecondComponent: "q"},
+		Components: []Substructure{{"r", "s"}, {"t", ""}},
+	}
+	// Act
+	result, err := BuildLine(source, "N", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "N|1|DIL^1&10^a&b^&mg|X^^^|^p&q|^r&s\\^t", result)
+}
+func TestBuildLine_SubstructureTooDeep(t *testing.T) {
+	// Arrange
+	source := TooDeepSubstructureRecord{}
+	// Act
+	_, err := BuildLine(source, "N", 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrAnnotationParsingSubstructureTooDeep)
+}
+func TestBuildLine_DefaultRecordZero(t *testing.T) {
+	// Arrange
+	source := DefaultRecord{Codes: []string{"A", ""}}
+	// Act
+	result, err := BuildLine(source, "D", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "D|1|P|1|N|^LIS2-A2|12:00|A\\X", result)
+}
+func TestBuildLine_DefaultNotAllowedValue(t *testing.T) {
+	// Arrange
+	type DefaultEnumRecord struct {
+		Code string `astm:"3,enum:A|B,default:C"`
+	}
+	// Act
+	_, err := BuildLine(DefaultEnumRecord{}, "D", 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineBuildingInvalidEnumValue)
+}
+func TestBuildLine_DefaultMaxLength(t *testing.T) {
+	// Arrange
+	type DefaultMaxLengthRecord struct {
+		Name string `astm:"3,maxlen:3,default:ABCD"`
+	}
+	// Act
+	_, err := BuildLine(DefaultMaxLengthRecord{}, "D", 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineBuildingMaxLengthExceeded)
+}
+func TestBuildLine_DefaultEscaped(t *testing.T) {
+	// Arrange
+	type DefaultDelimiterRecord struct {
+		Note string `astm:"3,default:a^b"`
+	}
+	config.EscapeOutputStrings = true
+	// Act
+	result, err := BuildLine(DefaultDelimiterRecord{}, "D", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "D|1|a&^b", result)
+	teardown()
+}
+func TestBuildLine_DefaultRecordValues(t *testing.T) {
+	// Arrange
+	pointer := "Y"
+	source := DefaultRecord{ProcessingID: "Q", Count: 2, Pointer: &pointer, Version: "V", Time: "13:00"}
+	// Act
+	result, err := BuildLine(source, "D", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "D|1|Q|2|Y|^V|13:00|", result)
+}
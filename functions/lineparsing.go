@@ -13,6 +13,10 @@ import (
 )
 
 func ParseLine(inputLine string, targetStruct interface{}, recordAnnotation models.AstmStructAnnotation, sequenceNumber int, config *astmmodels.Configuration) (nameOk bool, err error) {
+	return parseLine(inputLine, targetStruct, recordAnnotation, sequenceNumber, config, nil)
+}
+
+func parseLine(inputLine string, targetStruct interface{}, recordAnnotation models.AstmStructAnnotation, sequenceNumber int, config *astmmodels.Configuration, report *astmmodels.UnmarshalReport) (nameOk bool, err error) {
 	// Check for input line length
 	if len(inputLine) == 0 {
 		return false, errmsg.ErrLineParsingEmptyInput
@@ -27,15 +31,25 @@ func ParseLine(inputLine string, targetStruct interface{}, recordAnnotation mode
 		if len(inputLine) < 5 {
 			return false, errmsg.ErrLineParsingHeaderTooShort
 		}
-		// Override delimiters
+		// The delimiter definition ends at the next field delimiter (or at the end of a bare header)
+		definitionEnd := len(inputLine)
+		if index := strings.Index(inputLine[2:], inputLine[1:2]); index >= 0 {
+			definitionEnd = index + 2
+		}
+		if definitionEnd < 5 {
+			return false, errmsg.ErrLineParsingHeaderTooShort
+		}
+		// Override delimiters (the repeat delimiter takes the characters before the component and escape delimiters)
 		config.Delimiters.Field = string(inputLine[1])
-		config.Delimiters.Repeat = string(inputLine[2])
-		config.Delimiters.Component = string(inputLine[3])
-		config.Delimiters.Escape = string(inputLine[4])
+		config.Delimiters.Repeat = inputLine[2 : definitionEnd-2]
+		config.Delimiters.Component = string(inputLine[definitionEnd-2])
+		config.Delimiters.Escape = string(inputLine[definitionEnd-1])
 		// Place the fix segment into the inputFields
-		inputFields = []string{inputLine[0:1], inputLine[1:5]}
-		// Add the rest of the inputLine split by the field delimiter
-		inputFields = append(inputFields, splitStringWithEscape(inputLine[6:], config.Delimiters.Field, config.Delimiters.Escape)...)
+		inputFields = []string{inputLine[0:1], inputLine[1:definitionEnd]}
+		// Add the rest of the inputLine split by the field delimiter (a bare header has no more fields)
+		if len(inputLine) > definitionEnd+1 {
+			inputFields = append(inputFields, splitStringWithEscape(inputLine[definitionEnd+1:], config.Delimiters.Field, config.Delimiters.Escape)...)
+		}
 	} else {
 		// Split the input with the field delimiter
 		inputFields = splitStringWithEscape(inputLine, config.Delimiters.Field, config.Delimiters.Escape)
@@ -64,15 +78,18 @@ func ParseLine(inputLine string, targetStruct interface{}, recordAnnotation mode
 
 	// Check for validity of the sequence number (error only if enforced)
 	if inputFields[1] != strconv.Itoa(sequenceNumber) && inputLine[0] != 'H' && config.EnforceSequenceNumberCheck {
-		return true, errmsg.ErrLineParsingSequenceNumberMismatch
+		return true, addRecordPosition(addValue(errmsg.ErrLineParsingSequenceNumberMismatch, inputFields[1]), inputFields)
 	}
 
 	// Process the target structure
 	targetTypes, targetValues, _, err := ProcessStructReflection(targetStruct)
 	if err != nil {
-		return true, err
+		return true, addRecordPosition(err, inputFields)
 	}
 
+	// Conversion errors collected in lenient mode
+	var parseErrors errmsg.ParseErrors
+
 	// Iterate over the inputFields of the targetStruct struct
 	for i, targetType := range targetTypes {
 		// Parse the targetStruct field targetFieldAnnotation
@@ -82,26 +99,29 @@ func ParseLine(inputLine string, targetStruct interface{}, recordAnnotation mode
 				// If the annotation is missing, skip this field
 				continue
 			} else {
-				return true, err
+				return true, addRecordPosition(addFieldPosition(err, 0, 0, 0, targetType.Name), inputFields)
 			}
 		}
 
 		// Check for fieldPos not being lower than 3 (first 2 are reserved for line name and sequence number)
 		if targetFieldAnnotation.FieldPos < 3 {
-			return true, errmsg.ErrLineParsingReservedFieldPosReference
+			return true, addRecordPosition(addFieldPosition(errmsg.ErrLineParsingReservedFieldPosReference, targetFieldAnnotation.FieldPos, 0, 0, targetType.Name), inputFields)
 		}
 
-		// Not enough inputFields or empty inputField
-		if len(inputFields) < targetFieldAnnotation.FieldPos || inputFields[targetFieldAnnotation.FieldPos-1] == "" {
-			// If the field is required it's an error, otherwise skip it
+		// Save the current inputField (empty if there are not enough inputFields)
+		inputField := ""
+		if len(inputFields) >= targetFieldAnnotation.FieldPos {
+			inputField = inputFields[targetFieldAnnotation.FieldPos-1]
+		}
+		// Empty inputField: the default value is applied by setField, a required field is an error, otherwise skip it
+		_, hasDefault := targetFieldAnnotation.Attributes[constants.AttributeDefault]
+		if inputField == "" && !hasDefault {
 			if _, exists := targetFieldAnnotation.Attributes[constants.AttributeRequired]; exists {
-				return true, errmsg.ErrLineParsingRequiredInputFieldMissing
+				return true, addRecordPosition(addFieldPosition(errmsg.ErrLineParsingRequiredInputFieldMissing, targetFieldAnnotation.FieldPos, 0, targetFieldAnnotation.ComponentPos, targetType.Name), inputFields)
 			} else {
 				continue
 			}
 		}
-		// Save the current inputField
-		inputField := inputFields[targetFieldAnnotation.FieldPos-1]
 
 		if targetFieldAnnotation.IsArray {
 			// |rep1\rep2\rep3|
@@ -110,66 +130,96 @@ func ParseLine(inputLine string, targetStruct interface{}, recordAnnotation mode
 			arrayType := reflect.SliceOf(targetValues[i].Type().Elem())
 			arrayValue := reflect.MakeSlice(arrayType, len(repeats), len(repeats))
 			for j, repeat := range repeats {
-				if targetFieldAnnotation.IsSubstructure {
+				if targetFieldAnnotation.IsComponent {
+					// |comp1^comp2\comp1^comp2|
+					// The same component of each repeat (a missing component leaves the element empty)
+					component, _ := extractComponent(repeat, targetFieldAnnotation, config)
+					err = setComponent(component, arrayValue.Index(j), targetFieldAnnotation, config)
+					if err != nil {
+						err = addRecordPosition(addFieldPosition(addValue(err, component), targetFieldAnnotation.FieldPos, j+1, targetFieldAnnotation.ComponentPos, targetType.Name), inputFields)
+						if err = collectParseError(&parseErrors, err, config); err != nil {
+							return true, err
+						}
+					}
+				} else if targetFieldAnnotation.IsSubstructure {
 					// |comp1^comp2^comp3\comp1^comp2^comp3\comp1^comp2^comp3|
 					// Substructures (with components) in the array: use parseSubstructure
-					err = parseSubstructure(repeat, arrayValue.Index(j).Addr().Interface(), config)
+					err = parseSubstructure(repeat, arrayValue.Index(j).Addr().Interface(), 0, config)
 					if err != nil {
-						return true, err
+						err = addRecordPosition(addFieldPosition(addValue(err, repeat), targetFieldAnnotation.FieldPos, j+1, 0, targetType.Name), inputFields)
+						if err = collectParseError(&parseErrors, err, config); err != nil {
+							return true, err
+						}
 					}
 				} else {
 					// |value1\value2\value3|
 					// Simple values in the array
 					err = setField(repeat, arrayValue.Index(j), targetFieldAnnotation, config)
 					if err != nil {
-						return true, err
+						err = addRecordPosition(addFieldPosition(addValue(err, repeat), targetFieldAnnotation.FieldPos, j+1, 0, targetType.Name), inputFields)
+						if err = collectParseError(&parseErrors, err, config); err != nil {
+							return true, err
+						}
 					}
 				}
 
 			}
 			targetValues[i].Set(arrayValue)
 		} else if targetFieldAnnotation.IsComponent {
-			// |comp1^comp2^comp3|
-			// Field is a component
-			components := splitStringWithEscape(inputField, config.Delimiters.Component, config.Delimiters.Escape)
-			// Not enough components in the inputField
-			if len(components) < targetFieldAnnotation.ComponentPos {
+			// |comp1^comp2^comp3| or |comp1^sub1&sub2^comp3|
+			// Field is a component (or a sub-component), a component substructure contains the sub-components
+			component, exists := extractComponent(inputField, targetFieldAnnotation, config)
+			// Not enough components (or sub-components) in the inputField
+			if !exists && !hasDefault {
 				// Error if the component is required, skip otherwise
 				if _, exists := targetFieldAnnotation.Attributes[constants.AttributeRequired]; exists {
-					return true, errmsg.ErrLineParsingInputComponentsMissing
+					return true, addRecordPosition(addFieldPosition(addValue(errmsg.ErrLineParsingInputComponentsMissing, inputField), targetFieldAnnotation.FieldPos, 0, targetFieldAnnotation.ComponentPos, targetType.Name), inputFields)
 				} else {
 					continue
 				}
 			}
-			err = setField(components[targetFieldAnnotation.ComponentPos-1], targetValues[i], targetFieldAnnotation, config)
+			err = setComponent(component, targetValues[i], targetFieldAnnotation, config)
 			if err != nil {
-				return true, err
+				err = addRecordPosition(addFieldPosition(addValue(err, component), targetFieldAnnotation.FieldPos, 0, targetFieldAnnotation.ComponentPos, targetType.Name), inputFields)
+				if err = collectParseError(&parseErrors, err, config); err != nil {
+					return true, err
+				}
 			}
 		} else if targetFieldAnnotation.IsSubstructure {
 			// |comp1^comp2^comp3|
 			// If the field is a substructure use parseSubstructure to process it
-			err = parseSubstructure(inputField, targetValues[i].Addr().Interface(), config)
+			err = parseSubstructure(inputField, targetValues[i].Addr().Interface(), 0, config)
 			if err != nil {
-				return true, err
+				err = addRecordPosition(addFieldPosition(addValue(err, inputField), targetFieldAnnotation.FieldPos, 0, 0, targetType.Name), inputFields)
+				if err = collectParseError(&parseErrors, err, config); err != nil {
+					return true, err
+				}
 			}
 		} else {
 			// |field|
 			// Field is not an array or component (normal singular field)
 			err = setField(inputField, targetValues[i], targetFieldAnnotation, config)
 			if err != nil {
-				return true, err
+				err = addRecordPosition(addFieldPosition(addValue(err, inputField), targetFieldAnnotation.FieldPos, 0, 0, targetType.Name), inputFields)
+				if err = collectParseError(&parseErrors, err, config); err != nil {
+					return true, err
+				}
 			}
 		}
-		// Note: this could be a place to produce warnings about lost data
-		// if i == targetFieldCount-1 && len(inputFields) > targetFieldAnnotation.FieldPos
+	}
+	// Report and preserve the input data not mapped to any field (if requested)
+	collectUnmappedFields(inputFields, targetTypes, targetValues, recordAnnotation, config, report)
+	// Return the collected errors (lenient mode only)
+	if len(parseErrors) > 0 {
+		return true, parseErrors
 	}
 	// Return no error if everything went well
 	return true, nil
 }
 
-func parseSubstructure(inputString string, targetStruct interface{}, config *astmmodels.Configuration) (err error) {
-	// Split the input with the field delimiter
-	inputFields := splitStringWithEscape(inputString, config.Delimiters.Component, config.Delimiters.Escape)
+func parseSubstructure(inputString string, targetStruct interface{}, depth int, config *astmmodels.Configuration) (err error) {
+	// Split the input into components (or sub-components for the substructure of a component)
+	inputFields := splitSubstructure(inputString, depth, config)
 
 	// Process the target structure
 	targetTypes, targetValues, _, err := ProcessStructReflection(targetStruct)
@@ -177,6 +227,9 @@ func parseSubstructure(inputString string, targetStruct interface{}, config *ast
 		return err
 	}
 
+	// Conversion errors collected in lenient mode
+	var parseErrors errmsg.ParseErrors
+
 	// Iterate over the inputFields of the targetStruct struct
 	for i, targetType := range targetTypes {
 		// Parse the targetStruct field targetFieldAnnotation
@@ -186,41 +239,171 @@ func parseSubstructure(inputString string, targetStruct interface{}, config *ast
 				// If the annotation is missing, skip this field
 				continue
 			} else {
-				return err
+				return addFieldPosition(err, 0, 0, 0, targetType.Name)
 			}
 		}
 
-		// Not enough inputFields or empty inputField
-		if len(inputFields) < targetFieldAnnotation.FieldPos || inputFields[targetFieldAnnotation.FieldPos-1] == "" {
-			// If the field is required it's an error, otherwise skip it
+		// Nested values need the deeper delimiter levels (sub-components are the deepest)
+		if nestingLevels(targetFieldAnnotation) > constants.MaxSubstructureDepth-depth {
+			return addFieldPosition(errmsg.ErrAnnotationParsingSubstructureTooDeep, 0, 0, 0, targetType.Name)
+		}
+
+		// Save the current inputField (empty if there are not enough inputFields)
+		inputField := ""
+		if len(inputFields) >= targetFieldAnnotation.FieldPos {
+			inputField = inputFields[targetFieldAnnotation.FieldPos-1]
+		}
+		// Empty inputField: the default value is applied by setField, a required field is an error, otherwise skip it
+		_, hasDefault := targetFieldAnnotation.Attributes[constants.AttributeDefault]
+		if inputField == "" && !hasDefault {
 			if _, exists := targetFieldAnnotation.Attributes[constants.AttributeRequired]; exists {
-				return errmsg.ErrLineParsingRequiredInputFieldMissing
+				return addFieldPosition(errmsg.ErrLineParsingRequiredInputFieldMissing, 0, 0, targetFieldAnnotation.FieldPos, targetType.Name)
 			} else {
 				continue
 			}
 		}
-		// Save the current inputField
-		inputField := inputFields[targetFieldAnnotation.FieldPos-1]
 
-		// Set field is value
-		err = setField(inputField, targetValues[i], targetFieldAnnotation, config)
+		if targetFieldAnnotation.IsSubstructure {
+			// |comp1^sub1&sub2^comp3|
+			// Nested substructure: its fields are the sub-components
+			err = parseSubstructure(inputField, targetValues[i].Addr().Interface(), depth+1, config)
+		} else if targetFieldAnnotation.IsArray {
+			// |comp1^sub1&sub2&sub3^comp3|
+			// Array: the elements are the sub-components
+			subComponents := splitSubstructure(inputField, depth+1, config)
+			arrayValue := reflect.MakeSlice(targetValues[i].Type(), len(subComponents), len(subComponents))
+			for j, subComponent := range subComponents {
+				err = setField(subComponent, arrayValue.Index(j), targetFieldAnnotation, config)
+				if err != nil {
+					err = addFieldPosition(addValue(err, subComponent), 0, 0, targetFieldAnnotation.FieldPos, targetType.Name)
+					if err = collectParseError(&parseErrors, err, config); err != nil {
+						return err
+					}
+				}
+			}
+			targetValues[i].Set(arrayValue)
+			continue
+		} else if targetFieldAnnotation.IsComponent {
+			// |comp1^sub1&sub2^comp3|
+			// Component annotation: a single sub-component
+			subComponents := splitSubstructure(inputField, depth+1, config)
+			if len(subComponents) < targetFieldAnnotation.ComponentPos && !hasDefault {
+				// Error if the sub-component is required, skip otherwise
+				if _, exists := targetFieldAnnotation.Attributes[constants.AttributeRequired]; exists {
+					return addFieldPosition(addValue(errmsg.ErrLineParsingInputComponentsMissing, inputField), 0, 0, targetFieldAnnotation.FieldPos, targetType.Name)
+				} else {
+					continue
+				}
+			}
+			inputField = ""
+			if len(subComponents) >= targetFieldAnnotation.ComponentPos {
+				inputField = subComponents[targetFieldAnnotation.ComponentPos-1]
+			}
+			err = setField(inputField, targetValues[i], targetFieldAnnotation, config)
+		} else {
+			// Set field is value (the fields of a substructure are the components)
+			err = setField(inputField, targetValues[i], targetFieldAnnotation, config)
+		}
 		if err != nil {
-			return err
+			err = addFieldPosition(addValue(err, inputField), 0, 0, targetFieldAnnotation.FieldPos, targetType.Name)
+			if err = collectParseError(&parseErrors, err, config); err != nil {
+				return err
+			}
 		}
 	}
 
+	// Return the collected errors (lenient mode only)
+	if len(parseErrors) > 0 {
+		return parseErrors
+	}
 	// Return no error if everything went well
 	return nil
 }
 
+func splitSubstructure(input string, depth int, config *astmmodels.Configuration) []string {
+	// The substructure of a field (or repeat) contains components
+	if depth == 0 {
+		return splitStringWithEscape(input, config.Delimiters.Component, config.Delimiters.Escape)
+	}
+	// The substructure of a component contains sub-components (separated by the escape delimiter)
+	if input == "" {
+		return nil
+	}
+	return strings.Split(input, config.Delimiters.Escape)
+}
+
+func nestingLevels(annotation models.AstmFieldAnnotation) (levels int) {
+	// Each of these needs one more delimiter level below the position of the field
+	for _, nested := range []bool{annotation.IsArray, annotation.IsComponent, annotation.IsSubComponent, annotation.IsSubstructure} {
+		if nested {
+			levels++
+		}
+	}
+	return levels
+}
+
+func setComponent(value string, field reflect.Value, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (err error) {
+	// The fields of a component substructure are the sub-components
+	if annotation.IsSubstructure {
+		return parseSubstructure(value, field.Addr().Interface(), 1, config)
+	}
+	return setField(value, field, annotation, config)
+}
+
+func extractComponent(value string, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (result string, exists bool) {
+	// Select the component
+	components := splitStringWithEscape(value, config.Delimiters.Component, config.Delimiters.Escape)
+	if len(components) < annotation.ComponentPos {
+		return "", false
+	}
+	result = components[annotation.ComponentPos-1]
+	if !annotation.IsSubComponent {
+		return result, true
+	}
+	// Select the sub-component (separated by the escape delimiter, so it can not contain escape sequences)
+	subComponents := strings.Split(result, config.Delimiters.Escape)
+	if len(subComponents) < annotation.SubComponentPos {
+		return "", false
+	}
+	return subComponents[annotation.SubComponentPos-1], true
+}
+
 func setField(value string, field reflect.Value, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) (err error) {
 	// Ensure the field is settable
 	if !field.CanSet() {
 		// Field is not settable
 		return errmsg.ErrLineParsingNonSettableField
 	}
+	// Empty values take the default value (if any), otherwise leave the field at its zero value (pointers are not allocated)
+	if value == "" {
+		value = annotation.Attributes[constants.AttributeDefault]
+	}
+	if value == "" {
+		return nil
+	}
+	// Check the maximum length (if requested) and the allowed values (if any) without the escape characters
+	unescapedValue := filterStringEscapeChars(value, config.Delimiters.Escape)
+	if err = checkMaxLength(unescapedValue, annotation, config); err != nil {
+		return err
+	}
+	if err = checkEnumValue(unescapedValue, annotation, errmsg.ErrLineParsingInvalidEnumValue); err != nil {
+		return err
+	}
+	// Custom types parse themselves
+	if handled, err := unmarshalCustomField(value, field, annotation, config); handled {
+		return err
+	}
 	// Set the field value
 	switch field.Kind() {
+	case reflect.Ptr:
+		// Allocate a new value and set it only if parsing succeeded
+		elem := reflect.New(field.Type().Elem())
+		err = setField(value, elem.Elem(), annotation, config)
+		if err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
 	case reflect.String:
 		escaped := filterStringEscapeChars(value, config.Delimiters.Escape)
 		if field.Type().ConvertibleTo(reflect.TypeOf("")) {
@@ -229,94 +412,180 @@ func setField(value string, field reflect.Value, annotation models.AstmFieldAnno
 			field.Set(reflect.ValueOf(escaped))
 		}
 		return nil
-	case reflect.Int:
-		num, err := strconv.Atoi(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num, err := strconv.ParseInt(value, 10, field.Type().Bits())
 		if err != nil {
 			return errmsg.ErrLineParsingDataParsingError
 		}
-		field.Set(reflect.ValueOf(num))
+		field.SetInt(num)
 		return nil
-	case reflect.Float32:
-		num, err := strconv.ParseFloat(value, 32)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		num, err := strconv.ParseUint(value, 10, field.Type().Bits())
 		if err != nil {
 			return errmsg.ErrLineParsingDataParsingError
 		}
-		field.Set(reflect.ValueOf(float32(num)))
+		field.SetUint(num)
 		return nil
-	case reflect.Float64:
-		num, err := strconv.ParseFloat(value, 64)
+	case reflect.Float32, reflect.Float64:
+		num, err := strconv.ParseFloat(value, field.Type().Bits())
 		if err != nil {
 			return errmsg.ErrLineParsingDataParsingError
 		}
-		field.Set(reflect.ValueOf(num))
+		field.SetFloat(num)
+		return nil
+	case reflect.Bool:
+		// Besides the Go notations Y and N are accepted
+		switch value {
+		case "Y", "y":
+			field.SetBool(true)
+		case "N", "n":
+			field.SetBool(false)
+		default:
+			boolean, err := strconv.ParseBool(value)
+			if err != nil {
+				return errmsg.ErrLineParsingDataParsingError
+			}
+			field.SetBool(boolean)
+		}
 		return nil
 	// Check for time.Time type (it reflects as a Struct)
 	case reflect.Struct:
 		if field.Type() == reflect.TypeOf(time.Time{}) {
-			timeFormat := ""
-			switch len(value) {
-			case 8:
-				timeFormat = "20060102" // YYYYMMDD
-			case 14:
-				timeFormat = "20060102150405" // YYYYMMDDHHMMSS
-			default:
-				return errmsg.ErrLineParsingInvalidDateFormat
-			}
-			timeInLocation, err := time.ParseInLocation(timeFormat, value, config.TimeLocation)
+			timeValue, err := parseTime(value, annotation, config)
 			if err != nil {
-				return errmsg.ErrLineParsingDataParsingError
-			}
-			if _, exists := annotation.Attributes[constants.AttributeLongdate]; !exists && config.KeepShortDateTimeZone {
-				// Keep the short date time zone
-				timeInLocation = timeInLocation.In(config.TimeLocation)
-			} else {
-				// Set the time to UTC
-				timeInLocation = timeInLocation.UTC()
+				return err
 			}
-			field.Set(reflect.ValueOf(timeInLocation))
+			field.Set(reflect.ValueOf(timeValue))
 			return nil
-		} else {
-			// Note: option to handle other struct types here
 		}
 	}
 	// Return error if no type match was found (each successful parsing returns nil)
 	return errmsg.ErrLineParsingUnsupportedDataType
 }
 
-func splitStringWithEscape(input string, delimiter string, escape string) (result []string) {
-	delimiterRune := rune(delimiter[0])
-	escapeRune := rune(escape[0])
-	inputRunes := []rune(input)
-	start := 0
-	for i := 0; i < len(inputRunes); i++ {
-		if inputRunes[i] == delimiterRune {
-			result = append(result, string(inputRunes[start:i]))
-			start = i + 1
+func collectParseError(parseErrors *errmsg.ParseErrors, err error, config *astmmodels.Configuration) error {
+	// Errors already collected on a lower level are merged
+	if collected, ok := err.(errmsg.ParseErrors); ok {
+		*parseErrors = append(*parseErrors, collected...)
+		return nil
+	}
+	// In lenient mode conversion errors are collected and the field is left at its zero value
+	if config.LenientParsing && isConversionError(err) {
+		*parseErrors = append(*parseErrors, toParseError(err))
+		return nil
+	}
+	// Anything else stops the parsing
+	return err
+}
+
+func isConversionError(err error) bool {
+	return errors.Is(err, errmsg.ErrLineParsingDataParsingError) ||
+		errors.Is(err, errmsg.ErrLineParsingInvalidDateFormat) ||
+		errors.Is(err, errmsg.ErrLineParsingMaxLengthExceeded) ||
+		errors.Is(err, errmsg.ErrLineParsingInvalidEnumValue)
+}
+
+func updateParseError(err error, update func(parseError *errmsg.ParseError)) error {
+	// Collected errors are updated one by one
+	if collected, ok := err.(errmsg.ParseErrors); ok {
+		for _, parseError := range collected {
+			update(parseError)
+		}
+		return collected
+	}
+	parseError := toParseError(err)
+	update(parseError)
+	return parseError
+}
+
+func toParseError(err error) *errmsg.ParseError {
+	// Reuse the position collected so far or start a new one
+	var parseError *errmsg.ParseError
+	if errors.As(err, &parseError) {
+		return parseError
+	}
+	return &errmsg.ParseError{Err: err}
+}
+
+func addValue(err error, value string) error {
+	// The innermost (most specific) value is kept
+	return updateParseError(err, func(parseError *errmsg.ParseError) {
+		if parseError.Value == "" {
+			parseError.Value = value
 		}
-		if i == len(inputRunes)-1 {
-			result = append(result, string(inputRunes[start:i+1]))
+	})
+}
+
+func addFieldPosition(err error, fieldPos int, repeatIndex int, componentPos int, fieldName string) error {
+	// Positions are only set if known, the struct field name is prepended to the path collected so far
+	return updateParseError(err, func(parseError *errmsg.ParseError) {
+		if fieldPos > 0 {
+			parseError.FieldPos = fieldPos
 		}
-		if inputRunes[i] == escapeRune {
-			i++
-			continue
+		if repeatIndex > 0 {
+			parseError.RepeatIndex = repeatIndex
 		}
+		if componentPos > 0 {
+			parseError.ComponentPos = componentPos
+		}
+		parseError.FieldPath = joinFieldPath(fieldName, parseError.FieldPath)
+	})
+}
+
+func addRecordPosition(err error, inputFields []string) error {
+	// Record type and sequence number come from the first two input fields
+	return updateParseError(err, func(parseError *errmsg.ParseError) {
+		parseError.RecordType = inputFields[0]
+		parseError.SequenceNumber, _ = strconv.Atoi(inputFields[1])
+	})
+}
+
+func joinFieldPath(parent string, child string) string {
+	if parent == "" {
+		return child
+	}
+	if child == "" {
+		return parent
 	}
-	return result
+	return parent + "." + child
 }
 
-func filterStringEscapeChars(input string, escape string) string {
-	var builder strings.Builder
+func splitStringWithEscape(input string, delimiter string, escape string) (result []string) {
+	delimiterRunes := []rune(delimiter)
 	escapeRune := rune(escape[0])
 	inputRunes := []rune(input)
+	// Empty input has no parts
+	if len(inputRunes) == 0 {
+		return nil
+	}
+	start := 0
 	for i := 0; i < len(inputRunes); i++ {
 		if inputRunes[i] == escapeRune {
+			// The escaped character is never a delimiter
 			i++
-			if i < len(inputRunes) {
-				builder.WriteRune(inputRunes[i])
-			}
-		} else {
-			builder.WriteRune(inputRunes[i])
+		} else if hasRunesAt(inputRunes, i, delimiterRunes) {
+			// The delimiter can have more than one character (e.g. a doubled repeat delimiter)
+			result = append(result, string(inputRunes[start:i]))
+			start = i + len(delimiterRunes)
+			i = start - 1
 		}
 	}
-	return builder.String()
+	// The part after the last delimiter (also when it ends with an escaped character)
+	return append(result, string(inputRunes[start:]))
+}
+
+func hasRunesAt(input []rune, position int, runes []rune) bool {
+	if position+len(runes) > len(input) {
+		return false
+	}
+	for i, r := range runes {
+		if input[position+i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func filterStringEscapeChars(input string, escape string) string {
+	return astmmodels.Delimiters{Escape: escape}.UnescapeValue(input)
 }
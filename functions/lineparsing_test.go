@@ -54,6 +54,71 @@ func TestParseLine_MultitypeRecord(t *testing.T) {
 	assert.Equal(t, expectedShortTime, target.Date)
 }
 
+func TestParseLine_MultitypePointerRecord(t *testing.T) {
+	// Arrange
+	input := "T|1|string|0||3.14159265|20060102"
+	target := MultitypePointerRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "string", *target.String)
+	assert.Equal(t, 0, *target.Int)
+	assert.Nil(t, target.Float32)
+	assert.Equal(t, 3.14159265, *target.Float64)
+	assert.Equal(t, time.Date(2006, 1, 2, 0, 0, 0, 0, config.TimeLocation), *target.Date)
+}
+
+func TestParseLine_PointerComponentRecord(t *testing.T) {
+	// Arrange
+	input := "T|1|1^^three|Y"
+	target := PointerComponentRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 1, *target.First)
+	assert.Nil(t, target.Second)
+	assert.Equal(t, "three", *target.Third)
+	assert.True(t, *target.Flag)
+}
+
+func TestParseLine_PointerParsingError(t *testing.T) {
+	// Arrange
+	input := "T|1|x"
+	target := PointerComponentRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	assert.Nil(t, target.First)
+}
+
+func TestParseLine_ScalarKindsRecord(t *testing.T) {
+	// Arrange
+	input := "T|1|-8|-16|-32|-64|1|8|16|32|64|true|42"
+	target := ScalarKindsRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, ScalarKindsRecord{
+		Int8: -8, Int16: -16, Int32: -32, Int64: -64,
+		Uint: 1, Uint8: 8, Uint16: 16, Uint32: 32, Uint64: 64,
+		Bool: true, NamedInt: 42,
+	}, target)
+}
+
+func TestParseLine_ScalarKindsOutOfRange(t *testing.T) {
+	// Arrange
+	input := "T|1|128"
+	target := ScalarKindsRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+}
+
 func TestParseLine_ComponentedRecord(t *testing.T) {
 	// Arrange
 	input := "T|1|first|second1^second2|third1^third2^third3"
@@ -99,6 +164,18 @@ func TestParseLine_HeaderRecord(t *testing.T) {
 	assert.Equal(t, "first", target.First)
 }
 
+func TestParseLine_BareHeaderRecord(t *testing.T) {
+	// Arrange
+	input := "H|\\^&"
+	target := HeaderRecord{}
+	// Act
+	nameOk, err := ParseLine(input, &target, createStructAnnotation("H"), 0, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, nameOk)
+	assert.Equal(t, "", target.First)
+}
+
 func TestParseLine_HeaderDelimiterChange(t *testing.T) {
 	// Arrange
 	input := "H/!*%/first/second1!second2/third1*third2"
@@ -118,6 +195,35 @@ func TestParseLine_HeaderDelimiterChange(t *testing.T) {
 	teardown()
 }
 
+func TestParseLine_HeaderDoubledRepeatDelimiter(t *testing.T) {
+	// Arrange
+	input := "H|\\\\^&|first|second1\\\\second2\\third|third1^third2"
+	target := HeaderDelimiterChange{}
+	// Act
+	nameOk, err := ParseLine(input, &target, createStructAnnotation("H"), 0, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, nameOk)
+	assert.Equal(t, "\\\\", config.Delimiters.Repeat)
+	assert.Equal(t, "first", target.First)
+	assert.Equal(t, []string{"second1", "second2\\third"}, target.Array)
+	assert.Equal(t, "third2", target.Comp2)
+	// Teardown
+	teardown()
+}
+
+func TestParseLine_HeaderDelimitersTooShort(t *testing.T) {
+	// Arrange
+	input := "H|\\^|first"
+	target := HeaderRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("H"), 0, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingHeaderTooShort)
+	// Teardown
+	teardown()
+}
+
 func TestParseLine_MissingData(t *testing.T) {
 	// Arrange
 	input := "T|1|first||third"
@@ -152,7 +258,7 @@ func TestParseLine_MissingRequiredComponent(t *testing.T) {
 	// Act
 	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
 	// Assert
-	assert.EqualError(t, err, errmsg.ErrLineParsingInputComponentsMissing.Error())
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingInputComponentsMissing)
 }
 
 func TestParseLine_MissingDataAtTheEnd(t *testing.T) {
@@ -222,7 +328,7 @@ func TestParseLine_MissingRequiredField(t *testing.T) {
 	nameOk, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
 	// Assert
 	assert.True(t, nameOk)
-	assert.EqualError(t, err, errmsg.ErrLineParsingRequiredInputFieldMissing.Error())
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingRequiredInputFieldMissing)
 }
 
 func TestParseLine_NotEnoughInputFields(t *testing.T) {
@@ -233,7 +339,7 @@ func TestParseLine_NotEnoughInputFields(t *testing.T) {
 	nameOk, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
 	// Assert
 	assert.True(t, nameOk)
-	assert.EqualError(t, err, errmsg.ErrLineParsingRequiredInputFieldMissing.Error())
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingRequiredInputFieldMissing)
 }
 
 func TestParseLine_SequenceNumberMismatch(t *testing.T) {
@@ -244,7 +350,7 @@ func TestParseLine_SequenceNumberMismatch(t *testing.T) {
 	nameOk, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
 	// Assert
 	assert.True(t, nameOk)
-	assert.EqualError(t, err, errmsg.ErrLineParsingSequenceNumberMismatch.Error())
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingSequenceNumberMismatch)
 }
 
 func TestParseLine_SequenceNumberMismatchWithoutEnforcing(t *testing.T) {
@@ -260,6 +366,112 @@ func TestParseLine_SequenceNumberMismatchWithoutEnforcing(t *testing.T) {
 	teardown()
 }
 
+func TestParseLine_ParseErrorFieldPosition(t *testing.T) {
+	// Arrange
+	input := "T|3|string|3|abc|3.14159265|20060102"
+	target := MultitypeRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 3, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.Equal(t, "T", parseError.RecordType)
+	assert.Equal(t, 3, parseError.SequenceNumber)
+	assert.Equal(t, 5, parseError.FieldPos)
+	assert.Equal(t, 0, parseError.RepeatIndex)
+	assert.Equal(t, 0, parseError.ComponentPos)
+	assert.Equal(t, "Float32", parseError.FieldPath)
+	assert.Equal(t, "abc", parseError.Value)
+	assert.EqualError(t, err, `data parsing error (record T|3, field 5, struct field Float32, value "abc")`)
+}
+func TestParseLine_ParseErrorComponentPosition(t *testing.T) {
+	// Arrange
+	input := "T|1|first^x"
+	target := IntComponentRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.Equal(t, 3, parseError.FieldPos)
+	assert.Equal(t, 2, parseError.ComponentPos)
+	assert.Equal(t, "Second", parseError.FieldPath)
+	assert.Equal(t, "x", parseError.Value)
+}
+func TestParseLine_ParseErrorRepeatedSubstructurePosition(t *testing.T) {
+	// Arrange
+	input := "T|1|a^1\\b^x"
+	target := IntSubstructureArrayRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.Equal(t, 3, parseError.FieldPos)
+	assert.Equal(t, 2, parseError.RepeatIndex)
+	assert.Equal(t, 2, parseError.ComponentPos)
+	assert.Equal(t, "Array.Second", parseError.FieldPath)
+	assert.Equal(t, "x", parseError.Value)
+}
+func TestParseLine_LenientParsingCollectsErrors(t *testing.T) {
+	// Arrange
+	input := "T|1|string|x|abc|3.14159265|20061"
+	target := MultitypeRecord{}
+	config.LenientParsing = true
+	// Act
+	nameOk, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.True(t, nameOk)
+	var parseErrors errmsg.ParseErrors
+	assert.ErrorAs(t, err, &parseErrors)
+	assert.Len(t, parseErrors, 3)
+	assert.Equal(t, 4, parseErrors[0].FieldPos)
+	assert.Equal(t, 5, parseErrors[1].FieldPos)
+	assert.Equal(t, 7, parseErrors[2].FieldPos)
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingInvalidDateFormat)
+	assert.Equal(t, "string", target.String)
+	assert.Equal(t, 0, target.Int)
+	assert.Equal(t, float32(0), target.Float32)
+	assert.Equal(t, 3.14159265, target.Float64)
+	assert.True(t, target.Date.IsZero())
+	// Teardown
+	teardown()
+}
+func TestParseLine_LenientParsingSubstructure(t *testing.T) {
+	// Arrange
+	input := "T|1|a^1\\b^x\\c^3"
+	target := IntSubstructureArrayRecord{}
+	config.LenientParsing = true
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	var parseErrors errmsg.ParseErrors
+	assert.ErrorAs(t, err, &parseErrors)
+	assert.Len(t, parseErrors, 1)
+	assert.Equal(t, 2, parseErrors[0].RepeatIndex)
+	assert.Equal(t, "Array.Second", parseErrors[0].FieldPath)
+	assert.Len(t, target.Array, 3)
+	assert.Equal(t, "b", target.Array[1].First)
+	assert.Equal(t, 0, target.Array[1].Second)
+	assert.Equal(t, 3, target.Array[2].Second)
+	// Teardown
+	teardown()
+}
+func TestParseLine_LenientParsingRequiredFieldMissing(t *testing.T) {
+	// Arrange
+	input := "T|1|first||third"
+	target := RequiredFieldRecord{}
+	config.LenientParsing = true
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.IsType(t, &errmsg.ParseError{}, err)
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingRequiredInputFieldMissing)
+	// Teardown
+	teardown()
+}
 func TestParseLine_ReservedFieldRecord(t *testing.T) {
 	// Arrange
 	input := "T|1"
@@ -268,7 +480,7 @@ func TestParseLine_ReservedFieldRecord(t *testing.T) {
 	nameOk, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
 	// Assert
 	assert.True(t, nameOk)
-	assert.EqualError(t, err, errmsg.ErrLineParsingReservedFieldPosReference.Error())
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingReservedFieldPosReference)
 }
 
 func TestParseLine_SubstructureRecord(t *testing.T) {
@@ -411,6 +623,60 @@ func TestSplitStringWithEscape_Mixed(t *testing.T) {
 	assert.Equal(t, "third", result[2])
 }
 
+func TestSplitStringWithEscape_EscapedLastCharacter(t *testing.T) {
+	// Arrange
+	input := "first|second&|"
+	// Act
+	result := splitStringWithEscape(input, config.Delimiters.Field, config.Delimiters.Escape)
+	// Assert
+	assert.Len(t, result, 2)
+	assert.Equal(t, "first", result[0])
+	assert.Equal(t, "second&|", result[1])
+}
+
+func TestSplitStringWithEscape_EscapedEscapeLastCharacter(t *testing.T) {
+	// Arrange
+	input := "first|second&&"
+	// Act
+	result := splitStringWithEscape(input, config.Delimiters.Field, config.Delimiters.Escape)
+	// Assert
+	assert.Len(t, result, 2)
+	assert.Equal(t, "first", result[0])
+	assert.Equal(t, "second&&", result[1])
+}
+
+func TestSplitStringWithEscape_TrailingEscapeCharacter(t *testing.T) {
+	// Arrange
+	input := "first|second&"
+	// Act
+	result := splitStringWithEscape(input, config.Delimiters.Field, config.Delimiters.Escape)
+	// Assert
+	assert.Len(t, result, 2)
+	assert.Equal(t, "first", result[0])
+	assert.Equal(t, "second&", result[1])
+}
+
+func TestSplitStringWithEscape_TrailingDelimiter(t *testing.T) {
+	// Arrange
+	input := "first|second|"
+	// Act
+	result := splitStringWithEscape(input, config.Delimiters.Field, config.Delimiters.Escape)
+	// Assert
+	assert.Len(t, result, 3)
+	assert.Equal(t, "first", result[0])
+	assert.Equal(t, "second", result[1])
+	assert.Equal(t, "", result[2])
+}
+
+func TestSplitStringWithEscape_MultiCharacterDelimiter(t *testing.T) {
+	// Arrange
+	input := "first\\\\second\\third\\\\fourth"
+	// Act
+	result := splitStringWithEscape(input, "\\\\", config.Delimiters.Escape)
+	// Assert
+	assert.Equal(t, []string{"first", "second\\third", "fourth"}, result)
+}
+
 func TestSplitStringWithEscape_EmptyFields(t *testing.T) {
 	// Arrange
 	input := "first||third"
@@ -489,3 +755,149 @@ func TestFilterEscapeChars_Unicode(t *testing.T) {
 	// Assert
 	assert.Equal(t, "őáúäö|", result)
 }
+
+func TestParseLine_RepeatedComponentRecord(t *testing.T) {
+	// Arrange
+	input := "C|1|^Diluent 1^05761.03\\^Diluent 2\\^Diluent 3^05762.01|x^20240131\\y\\z"
+	target := RepeatedComponentRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("C"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Diluent 1", "Diluent 2", "Diluent 3"}, target.Names)
+	assert.Equal(t, []string{"05761.03", "", "05762.01"}, target.Lots)
+	assert.Equal(t, "x", target.First)
+	assert.Equal(t, []int{20240131, 0, 0}, target.Expirations)
+}
+func TestParseLine_RepeatedComponentError(t *testing.T) {
+	// Arrange
+	input := "C|1||x^1\\y^two"
+	target := RepeatedComponentRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("C"), 1, config)
+	// Assert
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	assert.Equal(t, 2, parseError.RepeatIndex)
+	assert.Equal(t, 2, parseError.ComponentPos)
+}
+func TestParseLine_SubComponentRecord(t *testing.T) {
+	// Arrange
+	input := "S|1|DIL^1&10|1&2\\3\\5&6"
+	target := SubComponentRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("S"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "DIL", target.Code)
+	assert.Equal(t, "1", target.Dilution)
+	assert.Equal(t, "10", target.DilutionUnit)
+	assert.Equal(t, []int{2, 0, 6}, target.Flags)
+}
+func TestParseLine_SubComponentMissingRequired(t *testing.T) {
+	// Arrange
+	type RequiredSubComponentRecord struct {
+		Unit string `astm:"3.2.2,required"`
+	}
+	input := "S|1|DIL^1"
+	target := RequiredSubComponentRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("S"), 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingInputComponentsMissing)
+}
+func TestParseLine_NestedSubstructureRecord(t *testing.T) {
+	// Arrange
+	input := "N|1|DIL^1&10^a&b^x&mg|X\\Y|x^p&q|^r&s\\^t"
+	target := NestedSubstructureRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("N"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "DIL", target.Field.Code)
+	assert.Equal(t, "1", target.Field.Dilution.FirstComponent)
+	assert.Equal(t, "10", target.Field.Dilution.SecondComponent)
+	assert.Equal(t, []string{"a", "b"}, target.Field.Flags)
+	assert.Equal(t, "mg", target.Field.Unit)
+	assert.Len(t, target.Array, 2)
+	assert.Equal(t, "Y", target.Array[1].Code)
+	assert.Equal(t, "p", target.Component.FirstComponent)
+	assert.Equal(t, "q", target.Component.SecondComponent)
+	assert.Equal(t, []Substructure{{"r", "s"}, {"t", ""}}, target.Components)
+}
+func TestParseLine_NestedSubstructureError(t *testing.T) {
+	// Arrange
+	type NumericSubstructure struct {
+		Value int `astm:"2"`
+	}
+	type NumericSubstructureRecord struct {
+		Nested struct {
+			Numeric NumericSubstructure `astm:"2"`
+		} `astm:"3"`
+	}
+	input := "N|1|x^1&two"
+	target := NumericSubstructureRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("N"), 1, config)
+	// Assert
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	assert.Equal(t, 3, parseError.FieldPos)
+	assert.Equal(t, 2, parseError.ComponentPos)
+	assert.Equal(t, "Nested.Numeric.Value", parseError.FieldPath)
+}
+func TestParseLine_SubstructureTooDeep(t *testing.T) {
+	// Arrange
+	input := "N|1|x"
+	target := TooDeepSubstructureRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("N"), 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrAnnotationParsingSubstructureTooDeep)
+}
+func TestParseLine_DefaultRecordEmpty(t *testing.T) {
+	// Arrange
+	input := "D|1"
+	target := DefaultRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("D"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P", target.ProcessingID)
+	assert.Equal(t, 1, target.Count)
+	assert.NotNil(t, target.Pointer)
+	assert.Equal(t, "N", *target.Pointer)
+	assert.Equal(t, "LIS2-A2", target.Version)
+	assert.Equal(t, "12:00", target.Time)
+	assert.Empty(t, target.Codes)
+}
+func TestParseLine_DefaultRecordValues(t *testing.T) {
+	// Arrange
+	input := "D|1|Q|2|Y|x^V|13:00|A\\\\B"
+	target := DefaultRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("D"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "Q", target.ProcessingID)
+	assert.Equal(t, 2, target.Count)
+	assert.Equal(t, "Y", *target.Pointer)
+	assert.Equal(t, "V", target.Version)
+	assert.Equal(t, "13:00", target.Time)
+	assert.Equal(t, []string{"A", "X", "B"}, target.Codes)
+}
+func TestParseLine_DefaultSatisfiesRequired(t *testing.T) {
+	// Arrange
+	type RequiredDefaultRecord struct {
+		ActionCode string `astm:"3,required,default:N"`
+	}
+	input := "O|1"
+	target := RequiredDefaultRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("O"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "N", target.ActionCode)
+}
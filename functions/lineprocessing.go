@@ -1,6 +1,8 @@
 package functions
 
 import (
+	"bufio"
+	"bytes"
 	"github.com/blutspende/go-astm/v3/enums/lineseparator"
 	"github.com/blutspende/go-astm/v3/errmsg"
 	"github.com/blutspende/go-astm/v3/models/astmmodels"
@@ -78,3 +80,32 @@ func BuildLines(input []string, config *astmmodels.Configuration) (output string
 
 	return output
 }
+
+func ScanLines(config *astmmodels.Configuration) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		// Nothing left to read
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		if !config.AutoDetectLineSeparator {
+			// Line separator provided in config, no auto-detect
+			if config.LineSeparator == "" {
+				return 0, nil, errmsg.ErrLineProcessingNoLineSeparator
+			}
+			if index := bytes.Index(data, []byte(config.LineSeparator)); index >= 0 {
+				return index + len(config.LineSeparator), data[:index], nil
+			}
+		} else {
+			// Auto-detect line separator: any CR or LF ends a line (the empty lines between CR and LF are left to the caller)
+			if index := bytes.IndexAny(data, lineseparator.CR+lineseparator.LF); index >= 0 {
+				return index + 1, data[:index], nil
+			}
+		}
+		// The last line does not need a separator
+		if atEOF {
+			return len(data), data, nil
+		}
+		// Request more data
+		return 0, nil, nil
+	}
+}
@@ -1,9 +1,11 @@
 package functions
 
 import (
+	"bufio"
 	"github.com/blutspende/go-astm/v3/enums/lineseparator"
 	"github.com/blutspende/go-astm/v3/errmsg"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -152,3 +154,56 @@ func TestBuildLines_ExplicitLFCR(t *testing.T) {
 	// Teardown
 	teardown()
 }
+
+// Line scanning
+func helperScanLines(input string) (lines []string, err error) {
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(ScanLines(config))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+func TestScanLines_AutoDetect(t *testing.T) {
+	// Arrange
+	input := "first\rsecond\nthird\r\nfourth"
+	// Act
+	lines, err := helperScanLines(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second", "third", "", "fourth"}, lines)
+}
+func TestScanLines_TrailingSeparator(t *testing.T) {
+	// Arrange
+	input := "first\rsecond\r"
+	// Act
+	lines, err := helperScanLines(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second"}, lines)
+}
+func TestScanLines_ExplicitCrLf(t *testing.T) {
+	// Arrange
+	input := "first\r\nsecond\nstill second"
+	config.LineSeparator = lineseparator.CRLF
+	config.AutoDetectLineSeparator = false
+	// Act
+	lines, err := helperScanLines(input)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second\nstill second"}, lines)
+	// Teardown
+	teardown()
+}
+func TestScanLines_NoLineSeparator(t *testing.T) {
+	// Arrange
+	input := "first"
+	config.LineSeparator = ""
+	config.AutoDetectLineSeparator = false
+	// Act
+	_, err := helperScanLines(input)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineProcessingNoLineSeparator)
+	// Teardown
+	teardown()
+}
@@ -0,0 +1,80 @@
+package functions
+
+import (
+	"fmt"
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/enums/maxlengthpolicy"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"strconv"
+	"unicode/utf8"
+)
+
+func applyMaxLength(value string, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration, report *astmmodels.MarshalReport) (result string, err error) {
+	// Only values longer than the maxlen attribute (validated by the annotation parsing) are affected
+	maxLength, exists := getMaxLength(annotation)
+	if !exists || utf8.RuneCountInString(value) <= maxLength {
+		return value, nil
+	}
+	// Handle the value according to the policy (the default is an error)
+	switch config.MaxLengthPolicy {
+	case maxlengthpolicy.Truncate:
+		return truncateValue(value, maxLength), nil
+	case maxlengthpolicy.TruncateWithWarning:
+		if report != nil {
+			report.TruncatedValues = append(report.TruncatedValues, astmmodels.TruncatedValue{
+				MaxLength: maxLength,
+				Value:     value,
+			})
+		}
+		return truncateValue(value, maxLength), nil
+	default:
+		return "", fmt.Errorf("%w (%d): %q", errmsg.ErrLineBuildingMaxLengthExceeded, maxLength, value)
+	}
+}
+
+func checkMaxLength(value string, annotation models.AstmFieldAnnotation, config *astmmodels.Configuration) error {
+	// Only if validation is requested
+	if !config.ValidateMaxLength {
+		return nil
+	}
+	maxLength, exists := getMaxLength(annotation)
+	if exists && utf8.RuneCountInString(value) > maxLength {
+		return fmt.Errorf("%w (%d)", errmsg.ErrLineParsingMaxLengthExceeded, maxLength)
+	}
+	return nil
+}
+
+func getMaxLength(annotation models.AstmFieldAnnotation) (maxLength int, exists bool) {
+	value, exists := annotation.Attributes[constants.AttributeMaxLength]
+	if !exists {
+		return 0, false
+	}
+	maxLength, err := strconv.Atoi(value)
+	return maxLength, err == nil
+}
+
+func truncateValue(value string, maxLength int) string {
+	// The value is not escaped yet, so any character can be the last one
+	return string([]rune(value)[:maxLength])
+}
+
+func addReportRecordPosition(report *astmmodels.MarshalReport, from int, recordType string, sequenceNumber int, fieldPos int) {
+	// The values truncated since from belong to the field
+	if report == nil {
+		return
+	}
+	for i := from; i < len(report.TruncatedValues); i++ {
+		report.TruncatedValues[i].RecordType = recordType
+		report.TruncatedValues[i].SequenceNumber = sequenceNumber
+		report.TruncatedValues[i].FieldPos = fieldPos
+	}
+}
+
+func truncatedValueCount(report *astmmodels.MarshalReport) int {
+	if report == nil {
+		return 0
+	}
+	return len(report.TruncatedValues)
+}
@@ -0,0 +1,115 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/enums/maxlengthpolicy"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildLine_MaxLengthWithinLimits(t *testing.T) {
+	// Arrange
+	source := MaxLengthRecord{Name: "Smith", Codes: []string{"AB", "C"}, First: "abc", Number: 42}
+	// Act
+	result, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|1|Smith|AB\\C|abc|42", result)
+}
+func TestBuildLine_MaxLengthError(t *testing.T) {
+	// Arrange
+	source := MaxLengthRecord{Name: "Schmidt"}
+	// Act
+	_, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineBuildingMaxLengthExceeded)
+	assert.ErrorContains(t, err, "Schmidt")
+}
+func TestBuildLine_MaxLengthTruncate(t *testing.T) {
+	// Arrange
+	config.MaxLengthPolicy = maxlengthpolicy.Truncate
+	source := MaxLengthRecord{Name: "Müllerová", Codes: []string{"ABC", "D"}, First: "abcd", Number: 123}
+	// Act
+	result, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|1|Mülle|AB\\D|abc|12", result)
+	teardown()
+}
+func TestBuildLine_MaxLengthTruncateEscaped(t *testing.T) {
+	// Arrange
+	config.MaxLengthPolicy = maxlengthpolicy.Truncate
+	config.EscapeOutputStrings = true
+	source := MaxLengthRecord{Name: "A^B^C^D"}
+	// Act
+	result, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|1|A&^B&^C|||0", result)
+	teardown()
+}
+func TestBuildLine_MaxLengthEscapedWithinLimits(t *testing.T) {
+	// Arrange
+	config.EscapeOutputStrings = true
+	source := MaxLengthRecord{Name: "A^B^C"}
+	// Act
+	result, err := BuildLine(source, "P", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|1|A&^B&^C|||0", result)
+	teardown()
+}
+func TestBuildLine_MaxLengthTruncateWithWarning(t *testing.T) {
+	// Arrange
+	config.MaxLengthPolicy = maxlengthpolicy.TruncateWithWarning
+	report := &astmmodels.MarshalReport{}
+	source := MaxLengthRecord{Name: "Schmidt", Codes: []string{"A", "BCD"}}
+	// Act
+	result, err := buildLine(source, "P", 2, config, report)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "P|2|Schmi|A\\BC||0", result)
+	assert.Equal(t, []astmmodels.TruncatedValue{
+		{RecordType: "P", SequenceNumber: 2, FieldPos: 3, MaxLength: 5, Value: "Schmidt"},
+		{RecordType: "P", SequenceNumber: 2, FieldPos: 4, MaxLength: 2, Value: "BCD"},
+	}, report.TruncatedValues)
+	teardown()
+}
+func TestParseLine_MaxLengthNotValidated(t *testing.T) {
+	// Arrange
+	input := "P|1|Schmidt"
+	target := MaxLengthRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("P"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "Schmidt", target.Name)
+}
+func TestParseLine_MaxLengthValidatedEscaped(t *testing.T) {
+	// Arrange
+	config.ValidateMaxLength = true
+	input := "P|1|A&^B&^C"
+	target := MaxLengthRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("P"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "A^B^C", target.Name)
+	teardown()
+}
+func TestParseLine_MaxLengthValidated(t *testing.T) {
+	// Arrange
+	config.ValidateMaxLength = true
+	input := "P|1|Smith|AB\\CDE"
+	target := MaxLengthRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("P"), 1, config)
+	// Assert
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingMaxLengthExceeded)
+	assert.Equal(t, 4, parseError.FieldPos)
+	assert.Equal(t, 2, parseError.RepeatIndex)
+	teardown()
+}
@@ -0,0 +1,137 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/enums/operator"
+	"github.com/blutspende/go-astm/v3/enums/valuekind"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseMeasurementValue_Numeric(t *testing.T) {
+	// Act
+	result := astmmodels.ParseMeasurementValue("1.50")
+	// Assert
+	assert.Equal(t, valuekind.Numeric, result.Kind)
+	assert.Equal(t, "", result.Operator)
+	assert.Equal(t, "1.50", result.Number.String())
+	assert.False(t, result.IsCensored())
+}
+func TestParseMeasurementValue_DecimalComma(t *testing.T) {
+	// Act
+	result := astmmodels.ParseMeasurementValue("8,11")
+	// Assert
+	assert.Equal(t, valuekind.Numeric, result.Kind)
+	assert.Equal(t, 8.11, result.Number.Float64())
+	assert.Equal(t, "8,11", result.Original)
+}
+func TestParseMeasurementValue_Censored(t *testing.T) {
+	// Act
+	lessThan := astmmodels.ParseMeasurementValue("<0.05")
+	greaterOrEqual := astmmodels.ParseMeasurementValue(">= 100")
+	// Assert
+	assert.Equal(t, valuekind.Numeric, lessThan.Kind)
+	assert.Equal(t, operator.LessThan, lessThan.Operator)
+	assert.Equal(t, "0.05", lessThan.Number.String())
+	assert.True(t, lessThan.IsCensored())
+	assert.Equal(t, operator.GreaterOrEqual, greaterOrEqual.Operator)
+	assert.Equal(t, "100", greaterOrEqual.Number.String())
+}
+func TestParseMeasurementValue_Titer(t *testing.T) {
+	// Act
+	result := astmmodels.ParseMeasurementValue("<1:20")
+	// Assert
+	assert.Equal(t, valuekind.Titer, result.Kind)
+	assert.Equal(t, operator.LessThan, result.Operator)
+	assert.Equal(t, "20", result.Number.String())
+}
+func TestParseMeasurementValue_Grade(t *testing.T) {
+	// Act
+	digit := astmmodels.ParseMeasurementValue("4+")
+	plusSigns := astmmodels.ParseMeasurementValue("+++")
+	// Assert
+	assert.Equal(t, valuekind.Grade, digit.Kind)
+	assert.Equal(t, "4", digit.Number.String())
+	assert.Equal(t, valuekind.Grade, plusSigns.Kind)
+	assert.Equal(t, "3", plusSigns.Number.String())
+}
+func TestParseMeasurementValue_Text(t *testing.T) {
+	// Act
+	result := astmmodels.ParseMeasurementValue("> POS")
+	// Assert
+	assert.Equal(t, valuekind.Text, result.Kind)
+	assert.Equal(t, "", result.Operator)
+	assert.Equal(t, "> POS", result.Text)
+	assert.True(t, result.Number.IsEmpty())
+}
+func TestParseLine_MeasurementValueRecord(t *testing.T) {
+	// Arrange
+	input := "M|1|>8|1:160|3+\\NEG|x^<0,5"
+	target := MeasurementValueRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("M"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, operator.GreaterThan, target.Value.Operator)
+	assert.NotNil(t, target.Pointer)
+	assert.Equal(t, valuekind.Titer, target.Pointer.Kind)
+	assert.Len(t, target.Array, 2)
+	assert.Equal(t, valuekind.Grade, target.Array[0].Kind)
+	assert.Equal(t, "NEG", target.Array[1].Text)
+	assert.Equal(t, 0.5, target.Component.Number.Float64())
+}
+func TestParseLine_MeasurementValueEmpty(t *testing.T) {
+	// Arrange
+	input := "M|1||"
+	target := MeasurementValueRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("M"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, astmmodels.MeasurementValue{}, target.Value)
+	assert.Nil(t, target.Pointer)
+}
+func TestBuildLine_MeasurementValueRecord(t *testing.T) {
+	// Arrange
+	pointer := astmmodels.ParseMeasurementValue("1:160")
+	source := MeasurementValueRecord{
+		Value:   astmmodels.ParseMeasurementValue("<0,05"),
+		Pointer: &pointer,
+		Array: []astmmodels.MeasurementValue{
+			{Kind: valuekind.Grade, Number: astmmodels.NewDecimal(2, 0)},
+			{Kind: valuekind.Text, Text: "NEG"},
+		},
+		Component: astmmodels.MeasurementValue{
+			Kind:     valuekind.Numeric,
+			Operator: operator.GreaterOrEqual,
+			Number:   astmmodels.NewDecimal(1005, 1),
+		},
+	}
+	// Act
+	result, err := BuildLine(source, "M", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "M|1|<0,05|1:160|2+\\NEG|^>=100.5", result)
+}
+func TestBuildLine_MeasurementValueEscaped(t *testing.T) {
+	// Arrange
+	config.EscapeOutputStrings = true
+	source := MeasurementValueRecord{Value: astmmodels.MeasurementValue{Kind: valuekind.Text, Text: "POS^2"}}
+	// Act
+	result, err := BuildLine(source, "M", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "M|1|POS&^2|||^", result)
+	teardown()
+}
+func TestParseLine_MeasurementValueEscaped(t *testing.T) {
+	// Arrange
+	input := "M|1|POS&^2"
+	target := MeasurementValueRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("M"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "POS^2", target.Value.Text)
+	assert.Equal(t, "POS^2", target.Value.Original)
+}
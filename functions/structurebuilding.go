@@ -7,6 +7,11 @@ import (
 )
 
 func BuildStruct(sourceStruct interface{}, sequenceNumber int, depth int, config *astmmodels.Configuration) (result []string, err error) {
+	return BuildStructWithReport(sourceStruct, sequenceNumber, depth, config, nil)
+}
+
+// BuildStructWithReport works like BuildStruct and adds the truncated values to the report (if not nil)
+func BuildStructWithReport(sourceStruct interface{}, sequenceNumber int, depth int, config *astmmodels.Configuration, report *astmmodels.MarshalReport) (result []string, err error) {
 	// Check for maximum depth
 	if depth >= constants.MaxDepth {
 		return nil, errmsg.ErrStructureParsingMaxDepthReached
@@ -33,14 +38,14 @@ func BuildStruct(sourceStruct interface{}, sequenceNumber int, depth int, config
 			for j := 0; j < sourceValues[i].Len(); j++ {
 				if sourceStructAnnotation.IsComposite {
 					// Composite source: recursively build the composite structure
-					subResult, err := BuildStruct(sourceValues[i].Index(j).Addr().Interface(), j+1, depth+1, config)
+					subResult, err := BuildStructWithReport(sourceValues[i].Index(j).Addr().Interface(), j+1, depth+1, config, report)
 					if err != nil {
 						return nil, err
 					}
 					result = append(result, subResult...)
 				} else {
 					// Non-composite source: build the single line
-					lineResult, err := BuildLine(sourceValues[i].Index(j).Addr().Interface(), sourceStructAnnotation.StructName, j+1, config)
+					lineResult, err := buildLine(sourceValues[i].Index(j).Addr().Interface(), sourceStructAnnotation.StructName, j+1, config, report)
 					if err != nil {
 						return nil, err
 					}
@@ -51,7 +56,7 @@ func BuildStruct(sourceStruct interface{}, sequenceNumber int, depth int, config
 			// Source is a single element
 			if sourceStructAnnotation.IsComposite {
 				// Composite source: recursively build the composite structure
-				subResult, err := BuildStruct(sourceValue, sequenceNumber, depth+1, config)
+				subResult, err := BuildStructWithReport(sourceValue, sequenceNumber, depth+1, config, report)
 				if err != nil {
 					return nil, err
 				}
@@ -63,7 +68,7 @@ func BuildStruct(sourceStruct interface{}, sequenceNumber int, depth int, config
 					seqNum = sequenceNumber
 				}
 				// Non-composite source: build the single line
-				lineResult, err := BuildLine(sourceValue, sourceStructAnnotation.StructName, seqNum, config)
+				lineResult, err := buildLine(sourceValue, sourceStructAnnotation.StructName, seqNum, config, report)
 				if err != nil {
 					return nil, err
 				}
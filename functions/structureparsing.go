@@ -10,6 +10,11 @@ import (
 )
 
 func ParseStruct(inputLines []string, targetStruct interface{}, lineIndex *int, sequenceNumber int, depth int, config *astmmodels.Configuration) (err error) {
+	return ParseStructWithReport(inputLines, targetStruct, lineIndex, sequenceNumber, depth, config, nil)
+}
+
+// ParseStructWithReport works like ParseStruct and adds the unmapped input data to the report (if not nil)
+func ParseStructWithReport(inputLines []string, targetStruct interface{}, lineIndex *int, sequenceNumber int, depth int, config *astmmodels.Configuration, report *astmmodels.UnmarshalReport) (err error) {
 	// Check for maximum depth
 	if depth >= constants.MaxDepth {
 		return errmsg.ErrStructureParsingMaxDepthReached
@@ -25,6 +30,9 @@ func ParseStruct(inputLines []string, targetStruct interface{}, lineIndex *int,
 		return err
 	}
 
+	// Conversion errors collected in lenient mode
+	var parseErrors errmsg.ParseErrors
+
 	// Iterate over the inputFields of the targetStruct struct
 	for i, targetType := range targetTypes {
 		// Parse the targetStruct field targetFieldAnnotation
@@ -49,7 +57,7 @@ func ParseStruct(inputLines []string, targetStruct interface{}, lineIndex *int,
 				nameOk := true
 				if targetStructAnnotation.IsComposite {
 					// Composite target: recursively parse the composite structure
-					err = ParseStruct(inputLines, elem.Addr().Interface(), lineIndex, seq, depth+1, config)
+					err = ParseStructWithReport(inputLines, elem.Addr().Interface(), lineIndex, seq, depth+1, config, report)
 					// If the error is a line type name mismatch, it means the end of the array
 					// Note: here an error is used to communicate the end of the array, it is not a real error
 					if errors.Is(err, errmsg.ErrStructureParsingLineTypeNameMismatch) {
@@ -57,9 +65,14 @@ func ParseStruct(inputLines []string, targetStruct interface{}, lineIndex *int,
 					}
 				} else {
 					// Non-composite target: parse the line into the new element
-					nameOk, err = ParseLine(inputLines[*lineIndex], elem.Addr().Interface(), targetStructAnnotation, seq, config)
+					reported := reportedDataCount(report)
+					nameOk, err = parseLine(inputLines[*lineIndex], elem.Addr().Interface(), targetStructAnnotation, seq, config, report)
 					// Increment the line index
 					*lineIndex++
+					addReportLinePosition(report, reported, *lineIndex)
+					if err != nil {
+						err = addLinePosition(err, *lineIndex)
+					}
 				}
 				// If the type name is a mismatch, it means the end of the array
 				if !nameOk {
@@ -68,7 +81,10 @@ func ParseStruct(inputLines []string, targetStruct interface{}, lineIndex *int,
 					break
 				}
 				if err != nil {
-					return err
+					err = addFieldPosition(err, 0, 0, 0, fmt.Sprintf("%s[%d]", targetType.Name, seq-1))
+					if err = collectParseError(&parseErrors, err, config); err != nil {
+						return err
+					}
 				}
 				// If no error, add the new element to the slice
 				targetValues[i].Set(reflect.Append(targetValues[i], elem))
@@ -77,9 +93,12 @@ func ParseStruct(inputLines []string, targetStruct interface{}, lineIndex *int,
 			// Single element structure
 			if targetStructAnnotation.IsComposite {
 				// Composite target: go further down the rabbit hole
-				err = ParseStruct(inputLines, targetValue, lineIndex, 1, depth+1, config)
+				err = ParseStructWithReport(inputLines, targetValue, lineIndex, 1, depth+1, config, report)
 				if err != nil {
-					return err
+					err = addFieldPosition(err, 0, 0, 0, targetType.Name)
+					if err = collectParseError(&parseErrors, err, config); err != nil {
+						return err
+					}
 				}
 			} else {
 				// Non-composite target: there is a single line to parse
@@ -98,10 +117,15 @@ func ParseStruct(inputLines []string, targetStruct interface{}, lineIndex *int,
 					seq = sequenceNumber
 				}
 				// Parse the line and increment the line index
-				nameOk, err := ParseLine(inputLines[*lineIndex], targetValue, targetStructAnnotation, seq, config)
+				reported := reportedDataCount(report)
+				nameOk, err := parseLine(inputLines[*lineIndex], targetValue, targetStructAnnotation, seq, config, report)
 				*lineIndex++
+				addReportLinePosition(report, reported, *lineIndex)
 				if err != nil {
-					return err
+					err = addFieldPosition(addLinePosition(err, *lineIndex), 0, 0, 0, targetType.Name)
+					if err = collectParseError(&parseErrors, err, config); err != nil {
+						return err
+					}
 				}
 				// If there is a type name mismatch but the target is optional it can be skipped, otherwise it's an error
 				if !nameOk {
@@ -110,12 +134,25 @@ func ParseStruct(inputLines []string, targetStruct interface{}, lineIndex *int,
 						*lineIndex--
 						continue
 					} else {
-						return fmt.Errorf("%w @ln %d", errmsg.ErrStructureParsingLineTypeNameMismatch, *lineIndex)
+						parseError := &errmsg.ParseError{Err: errmsg.ErrStructureParsingLineTypeNameMismatch, Line: *lineIndex}
+						parseError.RecordType, _ = splitByFirst(inputLines[*lineIndex-1], config.Delimiters.Field)
+						return addFieldPosition(parseError, 0, 0, 0, targetType.Name)
 					}
 				}
 			}
 		}
 	}
+	// Return the collected errors (lenient mode only)
+	if len(parseErrors) > 0 {
+		return parseErrors
+	}
 	// Return nil if everything went well
 	return nil
 }
+
+func addLinePosition(err error, lineNumber int) error {
+	// The line number is 1-based
+	return updateParseError(err, func(parseError *errmsg.ParseError) {
+		parseError.Line = lineNumber
+	})
+}
@@ -178,6 +178,34 @@ func TestParseStruct_UnexpectedLineTypeError(t *testing.T) {
 	err := ParseStruct(input, &target, &lineIndex, 1, 0, config)
 	// Assert
 	assert.True(t, errors.Is(err, errmsg.ErrStructureParsingLineTypeNameMismatch))
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.Equal(t, 2, parseError.Line)
+	assert.Equal(t, "U", parseError.RecordType)
+	assert.Equal(t, "CompositeRecordStruct.Record2", parseError.FieldPath)
+}
+func TestParseStruct_ParseErrorPosition(t *testing.T) {
+	// Arrange
+	input := []string{
+		"F|1|r1 first|12",
+		"S|1|21|r2 second",
+		"F|2|r1 first|x",
+		"S|2|21|r2 second",
+	}
+	target := CompositeArrayMessage{}
+	lineIndex := 0
+	// Act
+	err := ParseStruct(input, &target, &lineIndex, 1, 0, config)
+	// Assert
+	assert.ErrorIs(t, err, errmsg.ErrLineParsingDataParsingError)
+	var parseError *errmsg.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.Equal(t, 3, parseError.Line)
+	assert.Equal(t, "F", parseError.RecordType)
+	assert.Equal(t, 2, parseError.SequenceNumber)
+	assert.Equal(t, 4, parseError.FieldPos)
+	assert.Equal(t, "CompositeRecordArray[1].Record1.Second", parseError.FieldPath)
+	assert.Equal(t, "x", parseError.Value)
 }
 func TestParseStruct_EndOfCompositeArray(t *testing.T) {
 	// Arrange
@@ -215,7 +243,7 @@ func TestParseStruct_LinesDepletedError(t *testing.T) {
 	// Act
 	err := ParseStruct(input, &target, &lineIndex, 1, 0, config)
 	// Assert
-	assert.EqualError(t, err, errmsg.ErrStructureParsingInputLinesDepleted.Error())
+	assert.ErrorIs(t, err, errmsg.ErrStructureParsingInputLinesDepleted)
 }
 func TestParseStruct_SubnameMessage(t *testing.T) {
 	// Arrange
@@ -310,3 +338,32 @@ func TestParseStruct_SubnameMultiArrayMessage(t *testing.T) {
 	// Teardown
 	teardown()
 }
+func TestParseStruct_LenientParsingCollectsErrors(t *testing.T) {
+	// Arrange
+	input := []string{
+		"F|1|r1 first|x",
+		"S|1|21|r2 second",
+		"F|2|r1 first|12",
+		"S|1|y|r2 second",
+	}
+	target := CompositeArrayMessage{}
+	lineIndex := 0
+	config.LenientParsing = true
+	// Act
+	err := ParseStruct(input, &target, &lineIndex, 1, 0, config)
+	// Assert
+	var parseErrors errmsg.ParseErrors
+	assert.ErrorAs(t, err, &parseErrors)
+	assert.Len(t, parseErrors, 2)
+	assert.Equal(t, 1, parseErrors[0].Line)
+	assert.Equal(t, "CompositeRecordArray[0].Record1.Second", parseErrors[0].FieldPath)
+	assert.Equal(t, 4, parseErrors[1].Line)
+	assert.Equal(t, "CompositeRecordArray[1].Record2.First", parseErrors[1].FieldPath)
+	assert.Len(t, target.CompositeRecordArray, 2)
+	assert.Equal(t, "r1 first", target.CompositeRecordArray[0].Record1.First)
+	assert.Equal(t, 12, target.CompositeRecordArray[1].Record1.Second)
+	assert.Equal(t, "r2 second", target.CompositeRecordArray[1].Record2.Second)
+	assert.Equal(t, 4, lineIndex)
+	// Teardown
+	teardown()
+}
@@ -0,0 +1,294 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/models"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldClaim describes which parts of an input field are mapped by the annotations of a record
+type fieldClaim struct {
+	whole      bool
+	repeated   bool
+	components map[int]*componentClaim
+}
+
+// componentClaim describes which sub-components of a component are mapped (all of them if whole)
+type componentClaim struct {
+	whole         bool
+	subComponents map[int]bool
+}
+
+func (claim *fieldClaim) component(componentPos int) *componentClaim {
+	if claim.components[componentPos] == nil {
+		claim.components[componentPos] = &componentClaim{subComponents: make(map[int]bool)}
+	}
+	return claim.components[componentPos]
+}
+
+func ReportSkippedLines(inputLines []string, lineIndex int, config *astmmodels.Configuration, report *astmmodels.UnmarshalReport) {
+	// Only if a report is requested
+	if report == nil {
+		return
+	}
+	// Every line after the parsed ones is reported as a whole record
+	for i := lineIndex; i < len(inputLines); i++ {
+		inputFields := splitStringWithEscape(inputLines[i], config.Delimiters.Field, config.Delimiters.Escape)
+		unmappedData := newUnmappedData(inputFields, 0, 0, 0, 0, inputLines[i])
+		unmappedData.Line = i + 1
+		reportUnmappedData(unmappedData, report)
+	}
+}
+
+func collectUnmappedFields(inputFields []string, targetTypes []reflect.StructField, targetValues []reflect.Value, recordAnnotation models.AstmStructAnnotation, config *astmmodels.Configuration, report *astmmodels.UnmarshalReport) {
+	// Find the raw fields of the record (if any)
+	rawFieldsIndex := -1
+	for i, targetType := range targetTypes {
+		if targetType.Type == reflect.TypeOf(astmmodels.RawFields{}) {
+			rawFieldsIndex = i
+		}
+	}
+	// Only if a report is requested or the data has to be preserved
+	if report == nil && rawFieldsIndex < 0 {
+		return
+	}
+	unmappedFields := findUnmappedFields(inputFields, targetTypes, recordAnnotation, config)
+	// Report the unmapped data
+	if report != nil {
+		for _, unmappedData := range unmappedFields {
+			reportUnmappedData(unmappedData, report)
+		}
+	}
+	// Preserve the unmapped data in the raw fields
+	if rawFieldsIndex >= 0 && len(unmappedFields) > 0 {
+		rawFields := make(astmmodels.RawFields, len(unmappedFields))
+		for i, unmappedData := range unmappedFields {
+			rawFields[i] = astmmodels.RawField{
+				FieldPos:        unmappedData.FieldPos,
+				RepeatIndex:     unmappedData.RepeatIndex,
+				ComponentPos:    unmappedData.ComponentPos,
+				SubComponentPos: unmappedData.SubComponentPos,
+				Value:           unmappedData.Value,
+			}
+		}
+		targetValues[rawFieldsIndex].Set(reflect.ValueOf(rawFields))
+	}
+}
+
+func findUnmappedFields(inputFields []string, targetTypes []reflect.StructField, recordAnnotation models.AstmStructAnnotation, config *astmmodels.Configuration) (result []astmmodels.UnmappedData) {
+	// Collect the claims of the annotated fields
+	claims := make(map[int]*fieldClaim)
+	for _, targetType := range targetTypes {
+		targetFieldAnnotation, err := ParseAstmFieldAnnotation(targetType)
+		if err != nil {
+			continue
+		}
+		claim, exists := claims[targetFieldAnnotation.FieldPos]
+		if !exists {
+			claim = &fieldClaim{components: make(map[int]*componentClaim)}
+			claims[targetFieldAnnotation.FieldPos] = claim
+		}
+		if targetFieldAnnotation.IsComponent {
+			// A component array takes the component of each repeat
+			claim.repeated = claim.repeated || targetFieldAnnotation.IsArray
+			componentClaim := claim.component(targetFieldAnnotation.ComponentPos)
+			if targetFieldAnnotation.IsSubComponent {
+				componentClaim.subComponents[targetFieldAnnotation.SubComponentPos] = true
+			} else if targetFieldAnnotation.IsSubstructure {
+				// The fields of a component substructure are the sub-components
+				for _, substructureFieldAnnotation := range substructureAnnotations(targetType.Type, targetFieldAnnotation.IsArray) {
+					componentClaim.subComponents[substructureFieldAnnotation.FieldPos] = true
+				}
+			} else {
+				componentClaim.whole = true
+			}
+		} else if targetFieldAnnotation.IsSubstructure {
+			// The fields of the substructure are the components
+			claim.repeated = claim.repeated || targetFieldAnnotation.IsArray
+			for _, substructureField := range substructureFields(targetType.Type, targetFieldAnnotation.IsArray) {
+				substructureFieldAnnotation, err := ParseAstmFieldAnnotation(substructureField)
+				if err != nil {
+					continue
+				}
+				componentClaim := claim.component(substructureFieldAnnotation.FieldPos)
+				if substructureFieldAnnotation.IsSubstructure {
+					// The fields of a nested substructure are the sub-components
+					for _, nestedFieldAnnotation := range substructureAnnotations(substructureField.Type, false) {
+						componentClaim.subComponents[nestedFieldAnnotation.FieldPos] = true
+					}
+				} else if substructureFieldAnnotation.IsComponent {
+					// A component annotation is a single sub-component
+					componentClaim.subComponents[substructureFieldAnnotation.ComponentPos] = true
+				} else {
+					// Simple values and arrays (with the sub-components as elements) take the whole component
+					componentClaim.whole = true
+				}
+			}
+		} else {
+			// Simple fields and arrays of simple values take the whole field
+			claim.whole = true
+		}
+	}
+	// The name, the sequence number (and the subname) are always mapped
+	firstFieldPos := 3
+	if _, exists := recordAnnotation.Attributes[constants.AttributeSubname]; exists {
+		firstFieldPos = 4
+	}
+	// Collect everything not claimed
+	for fieldPos := firstFieldPos; fieldPos <= len(inputFields); fieldPos++ {
+		inputField := inputFields[fieldPos-1]
+		if inputField == "" {
+			continue
+		}
+		claim, exists := claims[fieldPos]
+		if !exists {
+			result = append(result, newUnmappedData(inputFields, fieldPos, 0, 0, 0, inputField))
+			continue
+		}
+		if claim.whole {
+			continue
+		}
+		repeats := splitStringWithEscape(inputField, config.Delimiters.Repeat, config.Delimiters.Escape)
+		for j, repeat := range repeats {
+			// Without an array only the first repeat is mapped
+			repeatIndex := 0
+			if claim.repeated {
+				repeatIndex = j + 1
+			} else if j > 0 {
+				result = append(result, newUnmappedData(inputFields, fieldPos, j+1, 0, 0, repeat))
+				continue
+			}
+			components := splitStringWithEscape(repeat, config.Delimiters.Component, config.Delimiters.Escape)
+			for k, component := range components {
+				if component == "" {
+					continue
+				}
+				componentClaim, exists := claim.components[k+1]
+				if !exists {
+					result = append(result, newUnmappedData(inputFields, fieldPos, repeatIndex, k+1, 0, component))
+					continue
+				}
+				if componentClaim.whole {
+					continue
+				}
+				// Only some sub-components are mapped (separated by the escape delimiter)
+				for l, subComponent := range strings.Split(component, config.Delimiters.Escape) {
+					if subComponent != "" && !componentClaim.subComponents[l+1] {
+						result = append(result, newUnmappedData(inputFields, fieldPos, repeatIndex, k+1, l+1, subComponent))
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+func mergeRawFields(fieldMap map[int]string, sourceTypes []reflect.StructField, sourceValues []reflect.Value, config *astmmodels.Configuration) {
+	// Find the raw fields of the record (if any)
+	for i, sourceType := range sourceTypes {
+		if sourceType.Type != reflect.TypeOf(astmmodels.RawFields{}) {
+			continue
+		}
+		// Put every raw field back to its original position
+		for _, rawField := range sourceValues[i].Interface().(astmmodels.RawFields) {
+			if rawField.RepeatIndex == 0 && rawField.ComponentPos == 0 {
+				fieldMap[rawField.FieldPos] = rawField.Value
+				continue
+			}
+			repeats := splitStringWithEscape(fieldMap[rawField.FieldPos], config.Delimiters.Repeat, config.Delimiters.Escape)
+			repeatIndex := max(rawField.RepeatIndex, 1)
+			if rawField.ComponentPos == 0 {
+				repeats = setAtPosition(repeats, repeatIndex, rawField.Value)
+			} else {
+				repeats = setAtPosition(repeats, repeatIndex, "")
+				components := splitStringWithEscape(repeats[repeatIndex-1], config.Delimiters.Component, config.Delimiters.Escape)
+				if rawField.SubComponentPos == 0 {
+					components = setAtPosition(components, rawField.ComponentPos, rawField.Value)
+				} else {
+					components = setAtPosition(components, rawField.ComponentPos, "")
+					subComponents := splitSubstructure(components[rawField.ComponentPos-1], 1, config)
+					subComponents = setAtPosition(subComponents, rawField.SubComponentPos, rawField.Value)
+					components[rawField.ComponentPos-1] = strings.Join(subComponents, config.Delimiters.Escape)
+				}
+				repeats[repeatIndex-1] = strings.Join(components, config.Delimiters.Component)
+			}
+			fieldMap[rawField.FieldPos] = strings.Join(repeats, config.Delimiters.Repeat)
+		}
+	}
+}
+
+func substructureFields(substructureType reflect.Type, isArray bool) (result []reflect.StructField) {
+	// The element type of an array of substructures
+	if isArray {
+		substructureType = substructureType.Elem()
+	}
+	for i := 0; i < substructureType.NumField(); i++ {
+		result = append(result, substructureType.Field(i))
+	}
+	return result
+}
+
+func substructureAnnotations(substructureType reflect.Type, isArray bool) (result []models.AstmFieldAnnotation) {
+	// Fields without a valid annotation are not mapped
+	for _, substructureField := range substructureFields(substructureType, isArray) {
+		substructureFieldAnnotation, err := ParseAstmFieldAnnotation(substructureField)
+		if err != nil {
+			continue
+		}
+		result = append(result, substructureFieldAnnotation)
+	}
+	return result
+}
+
+func setAtPosition(values []string, position int, value string) []string {
+	// Extend with empty values if needed, an empty value does not overwrite an existing one
+	for len(values) < position {
+		values = append(values, "")
+	}
+	if value != "" {
+		values[position-1] = value
+	}
+	return values
+}
+
+func newUnmappedData(inputFields []string, fieldPos int, repeatIndex int, componentPos int, subComponentPos int, value string) astmmodels.UnmappedData {
+	// Record type and sequence number come from the first two input fields
+	unmappedData := astmmodels.UnmappedData{
+		FieldPos:        fieldPos,
+		RepeatIndex:     repeatIndex,
+		ComponentPos:    componentPos,
+		SubComponentPos: subComponentPos,
+		Value:           value,
+	}
+	if len(inputFields) > 0 {
+		unmappedData.RecordType = inputFields[0]
+	}
+	if len(inputFields) > 1 {
+		unmappedData.SequenceNumber, _ = strconv.Atoi(inputFields[1])
+	}
+	return unmappedData
+}
+
+func reportUnmappedData(unmappedData astmmodels.UnmappedData, report *astmmodels.UnmarshalReport) {
+	report.UnmappedData = append(report.UnmappedData, unmappedData)
+}
+
+func reportedDataCount(report *astmmodels.UnmarshalReport) int {
+	if report == nil {
+		return 0
+	}
+	return len(report.UnmappedData)
+}
+
+func addReportLinePosition(report *astmmodels.UnmarshalReport, from int, lineNumber int) {
+	// The data reported by parseLine since from belongs to the line
+	if report == nil {
+		return
+	}
+	for i := from; i < len(report.UnmappedData); i++ {
+		report.UnmappedData[i].Line = lineNumber
+	}
+}
@@ -0,0 +1,193 @@
+package functions
+
+import (
+	"github.com/blutspende/go-astm/v3/enums/notation"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReportUnmappedFields_ExtraFields(t *testing.T) {
+	// Arrange
+	input := "T|1|first|second|third|fourth||sixth"
+	target := ThreeFieldRecord{}
+	report := &astmmodels.UnmarshalReport{}
+	// Act
+	_, err := parseLine(input, &target, createStructAnnotation("T"), 1, config, report)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []astmmodels.UnmappedData{
+		{RecordType: "T", SequenceNumber: 1, FieldPos: 6, Value: "fourth"},
+		{RecordType: "T", SequenceNumber: 1, FieldPos: 8, Value: "sixth"},
+	}, report.UnmappedData)
+}
+func TestReportUnmappedFields_ExtraComponents(t *testing.T) {
+	// Arrange
+	input := "T|1|first^2^third"
+	target := IntComponentRecord{}
+	report := &astmmodels.UnmarshalReport{}
+	// Act
+	_, err := parseLine(input, &target, createStructAnnotation("T"), 1, config, report)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []astmmodels.UnmappedData{
+		{RecordType: "T", SequenceNumber: 1, FieldPos: 3, ComponentPos: 3, Value: "third"},
+	}, report.UnmappedData)
+}
+func TestReportUnmappedFields_ExtraRepeats(t *testing.T) {
+	// Arrange
+	input := "T|1|first^second^third\\again^4"
+	target := RequiredComponentRecord{}
+	report := &astmmodels.UnmarshalReport{}
+	// Act
+	_, err := parseLine(input, &target, createStructAnnotation("T"), 1, config, report)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []astmmodels.UnmappedData{
+		{RecordType: "T", SequenceNumber: 1, FieldPos: 3, RepeatIndex: 2, Value: "again^4"},
+	}, report.UnmappedData)
+}
+func TestReportUnmappedFields_SubstructureArray(t *testing.T) {
+	// Arrange
+	input := "T|1|first|a^b^c\\d^e^f^g|third"
+	target := SubstructureArrayRecord{}
+	report := &astmmodels.UnmarshalReport{}
+	// Act
+	_, err := parseLine(input, &target, createStructAnnotation("T"), 1, config, report)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []astmmodels.UnmappedData{
+		{RecordType: "T", SequenceNumber: 1, FieldPos: 4, RepeatIndex: 2, ComponentPos: 4, Value: "g"},
+	}, report.UnmappedData)
+}
+func TestReportUnmappedFields_SubComponents(t *testing.T) {
+	// Arrange
+	input := "S|1|DIL^1&10&x|1&2&y\\3"
+	target := SubComponentRecord{}
+	report := &astmmodels.UnmarshalReport{}
+	// Act
+	_, err := parseLine(input, &target, createStructAnnotation("S"), 1, config, report)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []astmmodels.UnmappedData{
+		{RecordType: "S", SequenceNumber: 1, FieldPos: 3, ComponentPos: 2, SubComponentPos: 3, Value: "x"},
+		{RecordType: "S", SequenceNumber: 1, FieldPos: 4, RepeatIndex: 1, ComponentPos: 1, SubComponentPos: 1, Value: "1"},
+		{RecordType: "S", SequenceNumber: 1, FieldPos: 4, RepeatIndex: 1, ComponentPos: 1, SubComponentPos: 3, Value: "y"},
+		{RecordType: "S", SequenceNumber: 1, FieldPos: 4, RepeatIndex: 2, ComponentPos: 1, SubComponentPos: 1, Value: "3"},
+	}, report.UnmappedData)
+}
+func TestReportUnmappedFields_NestedSubstructure(t *testing.T) {
+	// Arrange
+	input := "N|1|DIL^1&10&extra^a&b^x&mg&more||p^q&r&s"
+	target := NestedSubstructureRecord{}
+	report := &astmmodels.UnmarshalReport{}
+	// Act
+	_, err := parseLine(input, &target, createStructAnnotation("N"), 1, config, report)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []astmmodels.UnmappedData{
+		{RecordType: "N", SequenceNumber: 1, FieldPos: 3, ComponentPos: 2, SubComponentPos: 3, Value: "extra"},
+		{RecordType: "N", SequenceNumber: 1, FieldPos: 3, ComponentPos: 4, SubComponentPos: 1, Value: "x"},
+		{RecordType: "N", SequenceNumber: 1, FieldPos: 3, ComponentPos: 4, SubComponentPos: 3, Value: "more"},
+		{RecordType: "N", SequenceNumber: 1, FieldPos: 5, ComponentPos: 1, Value: "p"},
+		{RecordType: "N", SequenceNumber: 1, FieldPos: 5, ComponentPos: 2, SubComponentPos: 3, Value: "s"},
+	}, report.UnmappedData)
+}
+func TestReportUnmappedFields_NotRequested(t *testing.T) {
+	// Arrange
+	input := "T|1|first|second|third|fourth"
+	target := ThreeFieldRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "first", target.First)
+}
+func TestReportUnmappedFields_LinePosition(t *testing.T) {
+	// Arrange
+	input := []string{
+		"R|1|first1|second1|third1",
+		"R|2|first2|second2|third2|fourth2",
+	}
+	target := RecordArrayStruct{}
+	lineIndex := 0
+	report := &astmmodels.UnmarshalReport{}
+	// Act
+	err := ParseStructWithReport(input, &target, &lineIndex, 1, 0, config, report)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []astmmodels.UnmappedData{
+		{Line: 2, RecordType: "R", SequenceNumber: 2, FieldPos: 6, Value: "fourth2"},
+	}, report.UnmappedData)
+}
+func TestReportSkippedLines(t *testing.T) {
+	// Arrange
+	input := []string{
+		"R|1|first1|second1|third1",
+		"C|1|comment",
+		"L|1|N",
+	}
+	report := &astmmodels.UnmarshalReport{}
+	// Act
+	ReportSkippedLines(input, 1, config, report)
+	// Assert
+	assert.Equal(t, []astmmodels.UnmappedData{
+		{Line: 2, RecordType: "C", SequenceNumber: 1, Value: "C|1|comment"},
+		{Line: 3, RecordType: "L", SequenceNumber: 1, Value: "L|1|N"},
+	}, report.UnmappedData)
+}
+func TestParseLine_RawFields(t *testing.T) {
+	// Arrange
+	input := "T|1|first|a^b^c|x^y^z^extra\\u^v|sixth"
+	target := RawFieldsRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("T"), 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "first", target.First)
+	assert.Equal(t, "a", target.Second)
+	assert.Equal(t, astmmodels.RawFields{
+		{FieldPos: 4, ComponentPos: 2, Value: "b"},
+		{FieldPos: 4, ComponentPos: 3, Value: "c"},
+		{FieldPos: 5, RepeatIndex: 1, ComponentPos: 4, Value: "extra"},
+		{FieldPos: 6, Value: "sixth"},
+	}, target.Raw)
+}
+func TestBuildLine_RawFields(t *testing.T) {
+	// Arrange
+	source := RawFieldsRecord{
+		First:  "edited",
+		Second: "a",
+		Array:  []SubstructureField{{"x", "y", "z"}, {"u", "v", ""}},
+		Raw: astmmodels.RawFields{
+			{FieldPos: 4, ComponentPos: 2, Value: "b"},
+			{FieldPos: 4, ComponentPos: 3, Value: "c"},
+			{FieldPos: 5, RepeatIndex: 1, ComponentPos: 4, Value: "extra"},
+			{FieldPos: 6, Value: "sixth"},
+		},
+	}
+	config.Notation = notation.Short
+	// Act
+	result, err := BuildLine(source, "T", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "T|1|edited|a^b^c|x^y^z^extra\\u^v|sixth", result)
+	// Teardown
+	teardown()
+}
+func TestRawFields_SubComponentRoundTrip(t *testing.T) {
+	// Arrange
+	input := "S|1|DIL^1&10&x"
+	target := RawFieldsSubComponentRecord{}
+	// Act
+	_, err := ParseLine(input, &target, createStructAnnotation("S"), 1, config)
+	assert.Nil(t, err)
+	result, err := BuildLine(target, "S", 1, config)
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, astmmodels.RawFields{
+		{FieldPos: 3, ComponentPos: 2, SubComponentPos: 2, Value: "10"},
+		{FieldPos: 3, ComponentPos: 2, SubComponentPos: 3, Value: "x"},
+	}, target.Raw)
+	assert.Equal(t, input, result)
+}
@@ -12,8 +12,25 @@ func Marshal(sourceStruct interface{}, configuration ...astmmodels.Configuration
 	if err != nil {
 		return nil, err
 	}
+	return marshal(sourceStruct, config, nil)
+}
+
+// MarshalWithReport works like Marshal and also reports the values truncated by the TruncateWithWarning max length policy
+func MarshalWithReport(sourceStruct interface{}, configuration ...astmmodels.Configuration) (result [][]byte, report *astmmodels.MarshalReport, err error) {
+	// Load configuration
+	config, err := loadConfiguration(configuration...)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Collect the report while building
+	report = &astmmodels.MarshalReport{}
+	result, err = marshal(sourceStruct, config, report)
+	return result, report, err
+}
+
+func marshal(sourceStruct interface{}, config *astmmodels.Configuration, report *astmmodels.MarshalReport) (result [][]byte, err error) {
 	// Build the lines from the source structure
-	lines, err := functions.BuildStruct(sourceStruct, 1, 0, config)
+	lines, err := functions.BuildStructWithReport(sourceStruct, 1, 0, config, report)
 	if err != nil {
 		return nil, err
 	}
@@ -25,3 +42,6 @@ func Marshal(sourceStruct interface{}, configuration ...astmmodels.Configuration
 	// Return the result and no error if everything went well
 	return result, nil
 }
+
+// AstmMarshaler is implemented by field types that convert themselves to an ASTM field value
+type AstmMarshaler = astmmodels.AstmMarshaler
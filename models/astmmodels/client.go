@@ -0,0 +1,14 @@
+package astmmodels
+
+// Configuration struct for the TCP client
+type ClientConfiguration struct {
+	MessageQueueSize int
+	Configuration    Configuration
+	Transmission     TransmissionConfiguration
+}
+
+var DefaultClientConfiguration = ClientConfiguration{
+	MessageQueueSize: 16,
+	Configuration:    DefaultConfiguration,
+	Transmission:     DefaultTransmissionConfiguration,
+}
@@ -0,0 +1,13 @@
+package astmmodels
+
+// AstmMarshaler is implemented by field types that convert themselves to an ASTM field (or component) value
+// The attributes are the annotation attributes of the field (e.g. layout)
+type AstmMarshaler interface {
+	MarshalASTM(attributes map[string]string, config *Configuration) (string, error)
+}
+
+// AstmUnmarshaler is implemented by field types that parse themselves from an ASTM field (or component) value
+// The value is passed as it is in the message, including escape characters
+type AstmUnmarshaler interface {
+	UnmarshalASTM(value string, attributes map[string]string, config *Configuration) error
+}
@@ -4,7 +4,9 @@ import (
 	"github.com/blutspende/bloodlab-common/encoding"
 	"github.com/blutspende/bloodlab-common/timezone"
 	"github.com/blutspende/go-astm/v3/enums/lineseparator"
+	"github.com/blutspende/go-astm/v3/enums/maxlengthpolicy"
 	"github.com/blutspende/go-astm/v3/enums/notation"
+	"strings"
 	"time"
 )
 
@@ -20,6 +22,9 @@ type Configuration struct {
 	RoundLastDecimal           bool
 	KeepShortDateTimeZone      bool
 	EscapeOutputStrings        bool
+	LenientParsing             bool
+	MaxLengthPolicy            string
+	ValidateMaxLength          bool
 	Delimiters                 Delimiters
 	TimeLocation               *time.Location
 }
@@ -35,6 +40,9 @@ var DefaultConfiguration = Configuration{
 	RoundLastDecimal:           true,
 	KeepShortDateTimeZone:      true,
 	EscapeOutputStrings:        false,
+	LenientParsing:             false,
+	MaxLengthPolicy:            maxlengthpolicy.Error,
+	ValidateMaxLength:          false,
 	Delimiters:                 DefaultDelimiters,
 	TimeLocation:               nil,
 }
@@ -53,3 +61,30 @@ var DefaultDelimiters = Delimiters{
 	Component: `^`,
 	Escape:    `&`,
 }
+
+// EscapeValue puts the escape delimiter before every delimiter character of the value
+func (d Delimiters) EscapeValue(value string) string {
+	var builder strings.Builder
+	for _, character := range value {
+		if strings.ContainsRune(d.Field+d.Repeat+d.Component+d.Escape, character) {
+			builder.WriteString(d.Escape)
+		}
+		builder.WriteRune(character)
+	}
+	return builder.String()
+}
+
+// UnescapeValue removes the escape delimiters of the value, the escaped characters are kept
+func (d Delimiters) UnescapeValue(value string) string {
+	var builder strings.Builder
+	escaped := false
+	for _, character := range value {
+		if !escaped && strings.ContainsRune(d.Escape, character) {
+			escaped = true
+			continue
+		}
+		builder.WriteRune(character)
+		escaped = false
+	}
+	return builder.String()
+}
@@ -0,0 +1,167 @@
+package astmmodels
+
+import (
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/enums/precision"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"strings"
+	"time"
+)
+
+// DateTime is a date/time field type keeping the precision and the time zone handling of the original value
+// Without an offset in the original value the time follows the same time zone rules as time.Time fields
+type DateTime struct {
+	Time      time.Time
+	Precision string // One of the precision enum constants
+	HasOffset bool   // The original value had a time zone offset, Time is kept in that offset
+	Layout    string // Go layout of the original value, if empty it is derived from Precision and HasOffset
+}
+
+// Go layouts of the ASTM date/time precisions indexed by the number of digits
+var dateTimeLayouts = map[int]string{
+	4:  "2006",           // YYYY
+	6:  "200601",         // YYYYMM
+	8:  "20060102",       // YYYYMMDD
+	10: "2006010215",     // YYYYMMDDHH
+	12: "200601021504",   // YYYYMMDDHHMM
+	14: "20060102150405", // YYYYMMDDHHMMSS
+}
+
+// Go layouts of the precisions used when a DateTime has no layout
+var precisionLayouts = map[string]string{
+	precision.Year:             dateTimeLayouts[4],
+	precision.Month:            dateTimeLayouts[6],
+	precision.Day:              dateTimeLayouts[8],
+	precision.Hour:             dateTimeLayouts[10],
+	precision.Minute:           dateTimeLayouts[12],
+	precision.Second:           dateTimeLayouts[14],
+	precision.FractionalSecond: dateTimeLayouts[14] + ".000",
+}
+
+// MarshalASTM produces the value at the precision of the original value (the layout attribute takes precedence)
+// Without a precision the value is formatted like a time.Time: YYYYMMDD, YYYYMMDDHHMMSS with the longdate attribute
+func (d DateTime) MarshalASTM(attributes map[string]string, config *Configuration) (string, error) {
+	// Zero time is empty
+	if d.Time.IsZero() {
+		return "", nil
+	}
+	// The layout comes from the annotation, the original value or the precision (in this order)
+	layout, exists := attributes[constants.AttributeLayout]
+	if !exists {
+		layout = d.Layout
+	}
+	if layout == "" {
+		layout, exists = precisionLayouts[d.Precision]
+		if !exists {
+			// Without a precision the field is formatted like a time.Time
+			layout = dateTimeLayouts[8]
+			if _, exists := attributes[constants.AttributeLongdate]; exists {
+				layout = dateTimeLayouts[14]
+			}
+		} else if d.HasOffset {
+			layout += "-0700"
+		}
+	}
+	// The original offset is kept, otherwise the time is converted to the config's timezone
+	if d.HasOffset {
+		return d.Time.Format(layout), nil
+	}
+	return d.Time.In(config.TimeLocation).Format(layout), nil
+}
+
+// UnmarshalASTM parses any ASTM date/time precision with an optional offset (or the layout attribute)
+func (d *DateTime) UnmarshalASTM(value string, attributes map[string]string, config *Configuration) (err error) {
+	// An explicit layout overrides the detection
+	layout, exists := attributes[constants.AttributeLayout]
+	if !exists {
+		layout, err = detectDateTimeLayout(value)
+		if err != nil {
+			return err
+		}
+	}
+	// An offset in the value takes precedence over the configured time zone
+	parsedTime, err := time.ParseInLocation(layout, value, config.TimeLocation)
+	if err != nil {
+		return errmsg.ErrLineParsingDataParsingError
+	}
+	*d = DateTime{
+		Time:      parsedTime,
+		Precision: layoutPrecision(layout),
+		HasOffset: layoutHasOffset(layout),
+		Layout:    layout,
+	}
+	// The time is kept in the original offset, otherwise it's handled like a time.Time (UTC for longdate fields)
+	if !d.HasOffset {
+		if _, exists := attributes[constants.AttributeLongdate]; !exists && config.KeepShortDateTimeZone {
+			d.Time = parsedTime.In(config.TimeLocation)
+		} else {
+			d.Time = parsedTime.UTC()
+		}
+	}
+	return nil
+}
+
+func layoutPrecision(layout string) string {
+	// The finest element of the layout determines the precision
+	switch {
+	case strings.Contains(layout, "05.0") || strings.Contains(layout, "05,0") ||
+		strings.Contains(layout, "05.9") || strings.Contains(layout, "05,9"):
+		return precision.FractionalSecond
+	case strings.Contains(layout, "05"):
+		return precision.Second
+	case strings.Contains(layout, "04"):
+		return precision.Minute
+	case strings.Contains(layout, "15") || strings.Contains(layout, "03"):
+		return precision.Hour
+	case strings.Contains(layout, "02") || strings.Contains(layout, "_2"):
+		return precision.Day
+	case strings.Contains(layout, "01") || strings.Contains(layout, "Jan"):
+		return precision.Month
+	default:
+		return precision.Year
+	}
+}
+
+func layoutHasOffset(layout string) bool {
+	return strings.Contains(layout, "-07") || strings.Contains(layout, "Z07") || strings.Contains(layout, "MST")
+}
+
+func detectDateTimeLayout(value string) (layout string, err error) {
+	// The date and time digits determine the precision
+	digits := 0
+	for digits < len(value) && value[digits] >= '0' && value[digits] <= '9' {
+		digits++
+	}
+	layout, exists := dateTimeLayouts[digits]
+	if !exists {
+		return "", errmsg.ErrLineParsingInvalidDateFormat
+	}
+	rest := value[digits:]
+	// Fractional seconds (only after full seconds)
+	if digits == 14 && len(rest) > 1 && (rest[0] == '.' || rest[0] == ',') {
+		fractionDigits := 1
+		for fractionDigits < len(rest) && rest[fractionDigits] >= '0' && rest[fractionDigits] <= '9' {
+			fractionDigits++
+		}
+		if fractionDigits == 1 {
+			return "", errmsg.ErrLineParsingInvalidDateFormat
+		}
+		layout += rest[0:1] + strings.Repeat("0", fractionDigits-1)
+		rest = rest[fractionDigits:]
+	}
+	// Time zone offset: Z, +HH, +HHMM or +HH:MM (and the same with -)
+	switch {
+	case rest == "":
+	case rest == "Z":
+		layout += "Z07:00"
+	case len(rest) == 3 && (rest[0] == '+' || rest[0] == '-'):
+		layout += "-07"
+	case len(rest) == 5 && (rest[0] == '+' || rest[0] == '-'):
+		layout += "-0700"
+	case len(rest) == 6 && (rest[0] == '+' || rest[0] == '-') && rest[3] == ':':
+		layout += "-07:00"
+	default:
+		return "", errmsg.ErrLineParsingInvalidDateFormat
+	}
+	return layout, nil
+}
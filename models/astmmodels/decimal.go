@@ -0,0 +1,157 @@
+package astmmodels
+
+import (
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number field type keeping the digits and the scale of the original value
+// The zero value is empty (an empty field), marshal produces the original text unless the value was rounded
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+	text     string
+}
+
+// NewDecimal creates the decimal unscaled * 10^-scale (e.g. 110 with scale 2 is 1.10)
+func NewDecimal(unscaled int64, scale int) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// NewDecimalFromFloat creates a decimal from a float rounded to the given number of decimals
+func NewDecimalFromFloat(value float64, scale int) (Decimal, error) {
+	if scale < 0 {
+		scale = 0
+	}
+	decimal, err := ParseDecimal(strconv.FormatFloat(value, 'f', scale, 64))
+	decimal.text = ""
+	return decimal, err
+}
+
+// ParseDecimal parses a decimal in the form [+-]digits[.digits] (digits can be omitted on one side of the point)
+func ParseDecimal(value string) (Decimal, error) {
+	// Separate the sign
+	digits := value
+	negative := false
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+	// Separate the integer and the fraction part
+	integerPart, fractionPart, _ := strings.Cut(digits, ".")
+	if integerPart == "" && fractionPart == "" {
+		return Decimal{}, errmsg.ErrDecimalInvalidFormat
+	}
+	for _, char := range integerPart + fractionPart {
+		if char < '0' || char > '9' {
+			return Decimal{}, errmsg.ErrDecimalInvalidFormat
+		}
+	}
+	// The digits without the point are the unscaled value
+	unscaled, _ := new(big.Int).SetString("0"+integerPart+fractionPart, 10)
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+	return Decimal{unscaled: unscaled, scale: len(fractionPart), text: value}, nil
+}
+
+// IsEmpty reports if the decimal has no value (zero value or empty field)
+func (d Decimal) IsEmpty() bool {
+	return d.unscaled == nil
+}
+
+// Scale returns the number of decimals
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// String returns the original text, or the canonical form if the decimal was created or rounded in code
+func (d Decimal) String() string {
+	if d.IsEmpty() {
+		return ""
+	}
+	if d.text != "" {
+		return d.text
+	}
+	// Insert the point before the last scale digits
+	digits := new(big.Int).Abs(d.unscaled).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.unscaled.Sign() < 0 {
+		digits = "-" + digits
+	}
+	return digits
+}
+
+// Float64 returns the nearest float value
+func (d Decimal) Float64() float64 {
+	if d.IsEmpty() {
+		return 0
+	}
+	value, _ := new(big.Rat).SetFrac(d.unscaled, pow10(d.scale)).Float64()
+	return value
+}
+
+// Cmp compares the numeric values: -1 if d < other, 0 if equal (1.1 equals 1.10), +1 if d > other
+// An empty decimal compares as zero
+func (d Decimal) Cmp(other Decimal) int {
+	left, right := d.unscaledOrZero(), other.unscaledOrZero()
+	if d.scale < other.scale {
+		left = new(big.Int).Mul(left, pow10(other.scale-d.scale))
+	} else if other.scale < d.scale {
+		right = new(big.Int).Mul(right, pow10(d.scale-other.scale))
+	}
+	return left.Cmp(right)
+}
+
+// Round returns the decimal with the given number of decimals, rounding half away from zero
+func (d Decimal) Round(scale int) Decimal {
+	if d.IsEmpty() {
+		return d
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	// More decimals only append zeros
+	if scale >= d.scale {
+		return Decimal{unscaled: new(big.Int).Mul(d.unscaled, pow10(scale-d.scale)), scale: scale}
+	}
+	// Fewer decimals: divide and round the remainder
+	divisor := pow10(d.scale - scale)
+	quotient, remainder := new(big.Int).QuoRem(d.unscaled, divisor, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(d.unscaled.Sign())))
+	}
+	return Decimal{unscaled: quotient, scale: scale}
+}
+
+// MarshalASTM produces the original text (see String)
+func (d Decimal) MarshalASTM(attributes map[string]string, config *Configuration) (string, error) {
+	return d.String(), nil
+}
+
+// UnmarshalASTM parses the value with ParseDecimal
+func (d *Decimal) UnmarshalASTM(value string, attributes map[string]string, config *Configuration) (err error) {
+	*d, err = ParseDecimal(value)
+	return err
+}
+
+func (d Decimal) unscaledOrZero() *big.Int {
+	if d.IsEmpty() {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
@@ -0,0 +1,124 @@
+package astmmodels
+
+import (
+	"github.com/blutspende/go-astm/v3/enums/operator"
+	"github.com/blutspende/go-astm/v3/enums/valuekind"
+	"strings"
+)
+
+// MeasurementValue is a result value field type separating the qualifiers from the numeric part
+// Marshal produces the original value, values created in code are built from the parts
+type MeasurementValue struct {
+	Original string  // The value as received (unescaped)
+	Kind     string  // One of the valuekind enum constants
+	Operator string  // One of the operator enum constants, empty for exact values
+	Number   Decimal // Numeric part: the value, the titer dilution (160 for 1:160) or the grade (3 for 3+)
+	Text     string  // The text of non-numeric values
+}
+
+// Operator symbols of censored values (two character symbols first, so they are matched before their prefixes)
+var operatorSymbols = []struct {
+	symbol   string
+	operator string
+}{
+	{"<=", operator.LessOrEqual},
+	{">=", operator.GreaterOrEqual},
+	{"<", operator.LessThan},
+	{">", operator.GreaterThan},
+}
+
+// IsCensored reports if the value is outside the measuring range (e.g. <0.05 or >100)
+func (m MeasurementValue) IsCensored() bool {
+	return m.Operator != ""
+}
+
+// String returns the original value, or the value built from the parts for values created in code
+func (m MeasurementValue) String() (result string) {
+	if m.Original != "" {
+		return m.Original
+	}
+	for _, operatorSymbol := range operatorSymbols {
+		if operatorSymbol.operator == m.Operator {
+			result = operatorSymbol.symbol
+		}
+	}
+	switch m.Kind {
+	case valuekind.Numeric:
+		return result + m.Number.String()
+	case valuekind.Titer:
+		return result + "1:" + m.Number.String()
+	case valuekind.Grade:
+		return result + m.Number.String() + "+"
+	default:
+		return m.Text
+	}
+}
+
+// MarshalASTM produces the value (see String) escaped like a string
+func (m MeasurementValue) MarshalASTM(attributes map[string]string, config *Configuration) (string, error) {
+	if config.EscapeOutputStrings {
+		return config.Delimiters.EscapeValue(m.String()), nil
+	}
+	return m.String(), nil
+}
+
+// UnmarshalASTM parses the value without escape characters with ParseMeasurementValue
+func (m *MeasurementValue) UnmarshalASTM(value string, attributes map[string]string, config *Configuration) error {
+	*m = ParseMeasurementValue(config.Delimiters.UnescapeValue(value))
+	return nil
+}
+
+// ParseMeasurementValue splits a result value into operator, numeric part and text, it never fails (unknown values are text)
+func ParseMeasurementValue(value string) (result MeasurementValue) {
+	result.Original = value
+	rest := strings.TrimSpace(value)
+	if rest == "" {
+		return result
+	}
+	// Separate the operator (a space can follow it)
+	for _, operatorSymbol := range operatorSymbols {
+		if strings.HasPrefix(rest, operatorSymbol.symbol) {
+			result.Operator = operatorSymbol.operator
+			rest = strings.TrimSpace(rest[len(operatorSymbol.symbol):])
+			break
+		}
+	}
+	// Titer notation (1:160)
+	if dilution, isTiter := strings.CutPrefix(rest, "1:"); isTiter {
+		if number, err := parseMeasurementNumber(dilution); err == nil {
+			result.Kind = valuekind.Titer
+			result.Number = number
+			return result
+		}
+	}
+	// Grade notation (3+ or +++)
+	if grade, isGrade := strings.CutSuffix(rest, "+"); isGrade {
+		if number, err := parseMeasurementNumber(grade); err == nil {
+			result.Kind = valuekind.Grade
+			result.Number = number
+			return result
+		}
+		if strings.Trim(rest, "+") == "" {
+			result.Kind = valuekind.Grade
+			result.Number = NewDecimal(int64(len(rest)), 0)
+			return result
+		}
+	}
+	// Numeric value
+	if number, err := parseMeasurementNumber(rest); err == nil {
+		result.Kind = valuekind.Numeric
+		result.Number = number
+		return result
+	}
+	// Anything else is text (an operator is only meaningful with a number)
+	return MeasurementValue{
+		Original: value,
+		Kind:     valuekind.Text,
+		Text:     strings.TrimSpace(value),
+	}
+}
+
+func parseMeasurementNumber(value string) (Decimal, error) {
+	// Some instruments use a decimal comma
+	return ParseDecimal(strings.Replace(value, ",", ".", 1))
+}
@@ -0,0 +1,15 @@
+package astmmodels
+
+// RawFields holds the input data of a record not claimed by any annotated field
+// A record field of this type is filled by unmarshal and merged back at the original positions by marshal
+type RawFields []RawField
+
+// RawField is a piece of input data with its original position
+// Positions are 1-based, 0 means that the whole field, repeat or component is stored
+type RawField struct {
+	FieldPos        int
+	RepeatIndex     int
+	ComponentPos    int
+	SubComponentPos int
+	Value           string
+}
@@ -0,0 +1,33 @@
+package astmmodels
+
+// UnmarshalReport lists the input data that was not mapped to any field of the target structure
+type UnmarshalReport struct {
+	UnmappedData []UnmappedData
+}
+
+// UnmappedData is a piece of input data discarded by unmarshal
+// Positions are 1-based, 0 means that the whole record, field, repeat or component is unmapped
+type UnmappedData struct {
+	Line            int
+	RecordType      string
+	SequenceNumber  int
+	FieldPos        int
+	RepeatIndex     int
+	ComponentPos    int
+	SubComponentPos int
+	Value           string
+}
+
+// MarshalReport lists the values truncated by marshal (with the TruncateWithWarning max length policy)
+type MarshalReport struct {
+	TruncatedValues []TruncatedValue
+}
+
+// TruncatedValue is a value longer than its maxlen attribute, positions are 1-based
+type TruncatedValue struct {
+	RecordType     string
+	SequenceNumber int
+	FieldPos       int
+	MaxLength      int
+	Value          string // The original value before truncation
+}
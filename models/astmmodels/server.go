@@ -0,0 +1,20 @@
+package astmmodels
+
+import "net"
+
+// Configuration struct for the TCP server
+type ServerConfiguration struct {
+	MaxConnections        int
+	MessageQueueSize      int
+	Configuration         Configuration
+	ConfigurationProvider func(remoteAddress net.Addr) Configuration
+	Transmission          TransmissionConfiguration
+}
+
+var DefaultServerConfiguration = ServerConfiguration{
+	MaxConnections:        0,
+	MessageQueueSize:      16,
+	Configuration:         DefaultConfiguration,
+	ConfigurationProvider: nil,
+	Transmission:          DefaultTransmissionConfiguration,
+}
@@ -0,0 +1,43 @@
+package astmmodels
+
+import (
+	"github.com/blutspende/go-astm/v3/enums/role"
+	"time"
+)
+
+// Configuration struct for the LIS01-A2 low-level transmission
+type TransmissionConfiguration struct {
+	Role                      string
+	ReplyTimeout              time.Duration
+	ReceiveTimeout            time.Duration
+	BusyRetryDelay            time.Duration
+	InstrumentContentionDelay time.Duration
+	ComputerContentionDelay   time.Duration
+	MaxRetransmissions        int
+	ReceiveQueueSize          int
+	Clock                     Clock
+}
+
+var DefaultTransmissionConfiguration = TransmissionConfiguration{
+	Role:                      role.Computer,
+	ReplyTimeout:              15 * time.Second,
+	ReceiveTimeout:            30 * time.Second,
+	BusyRetryDelay:            10 * time.Second,
+	InstrumentContentionDelay: 1 * time.Second,
+	ComputerContentionDelay:   20 * time.Second,
+	MaxRetransmissions:        6,
+	ReceiveQueueSize:          0,
+	Clock:                     nil,
+}
+
+// Clock used for the protocol timers, replaceable for deterministic testing
+type Clock interface {
+	After(duration time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock backed by the time package
+type SystemClock struct{}
+
+func (SystemClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
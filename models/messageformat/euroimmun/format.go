@@ -0,0 +1,46 @@
+package euroimmun
+
+import (
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"time"
+)
+
+// Euroimmun Analyzer I (interface version 10) record and message format declarations
+// The declarations capture the fields used by the analyzer, see examples/euroimmun_analyzer1_v10
+// Results are sent with a decimal comma and with censoring operators (e.g. >8), they are read as measurement values
+// The commented section numbers refer to the LIS02-A2 standard (see the lis02a2 package)
+
+// Record structures //
+
+type Patient struct {
+	LabAssignedPatientID string `astm:"4"` // 7.4
+}
+type Order struct {
+	UniversalTestID        lis02a2.StandardUniversalTestID `astm:"5"`          // 8.4.5
+	RequestedOrderDateTime time.Time                       `astm:"7,longdate"` // 8.4.7
+}
+type Result struct {
+	UniversalTestID lis02a2.StandardUniversalTestID `astm:"3"` // 9.3
+	Value           astmmodels.MeasurementValue     `astm:"4"` // 9.4
+	Units           string                          `astm:"5"` // 9.5
+}
+
+// Message structures //
+
+type PatientResult struct {
+	Patient      Patient `astm:"P"`
+	OrderResults []OrderResult
+}
+type OrderResult struct {
+	Order   Order    `astm:"O"`
+	Results []Result `astm:"R"`
+}
+
+// Messages //
+
+type ResultMessage struct {
+	Header         lis02a2.Header `astm:"H"`
+	PatientResults []PatientResult
+	Terminator     lis02a2.Terminator `astm:"L"`
+}
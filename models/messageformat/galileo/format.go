@@ -0,0 +1,68 @@
+package galileo
+
+import (
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"time"
+)
+
+// Immucor Galileo record and message format declarations
+// The declarations capture the fields used by the analyzer, see examples/galileo
+// The header and result records follow the standard and are taken from the lis02a2 package
+// The commented section numbers refer to the LIS02-A2 standard (see the lis02a2 package)
+
+// Record structures //
+
+type Patient struct {
+	PracticeAssignedPatientID string    `astm:"3"`   // 7.3
+	LastName                  string    `astm:"6.1"` // 7.6.1
+	FirstName                 string    `astm:"6.2"` // 7.6.2
+	DOB                       time.Time `astm:"8"`   // 7.8
+	Gender                    string    `astm:"9"`   // 7.9
+}
+type Order struct {
+	SpecimenID      string                          `astm:"3"`    // 8.4.3
+	UniversalTestID lis02a2.StandardUniversalTestID `astm:"5"`    // 8.4.5
+	Priority        lis02a2.Priority                `astm:"6"`    // 8.4.6
+	ActionCode      lis02a2.ActionCode              `astm:"12"`   // 8.4.12
+	SpecimenType    string                          `astm:"16.1"` // 8.4.16
+	SpecimenSource  string                          `astm:"16.2"` // 8.4.16
+}
+
+// DonorComment names the donor unit of a crossmatch order (e.g. C|1|L|Donor^R02460)
+type DonorComment struct {
+	CommentSource   string `astm:"3"`   // 10.3
+	Keyword         string `astm:"4.1"` // 10.4
+	DonorUnitNumber string `astm:"4.2"` // 10.4
+}
+
+// Message structures //
+
+type PatientOrder struct {
+	Patient     Patient `astm:"P"`
+	OrderGroups []OrderGroup
+}
+type OrderGroup struct {
+	Order         Order          `astm:"O"`
+	DonorComments []DonorComment `astm:"C,optional"`
+}
+type PatientResult struct {
+	Patient      Patient `astm:"P"`
+	OrderResults []OrderResult
+}
+type OrderResult struct {
+	Order   Order            `astm:"O"`
+	Results []lis02a2.Result `astm:"R"`
+}
+
+// Messages //
+
+type OrderMessage struct {
+	Header        lis02a2.Header `astm:"H"`
+	PatientOrders []PatientOrder
+	Terminator    lis02a2.Terminator `astm:"L"`
+}
+type ResultMessage struct {
+	Header         lis02a2.Header `astm:"H"`
+	PatientResults []PatientResult
+	Terminator     lis02a2.Terminator `astm:"L"`
+}
@@ -0,0 +1,96 @@
+package ihcom
+
+import (
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"time"
+)
+
+// Bio-Rad IH-Com (version 5.2) record and message format declarations
+// The declarations capture the fields used by the interface, see examples/ihcom_v52
+// The commented section numbers refer to the LIS02-A2 standard (see the lis02a2 package)
+// Fields without a section number are manufacturer specific
+// Messages with multiple patients may number every patient record with 1, unmarshal them with EnforceSequenceNumberCheck disabled
+
+// Record substructures //
+
+type Reagent struct {
+	Name           string    `astm:"1"`
+	Barcode        string    `astm:"2"`
+	LotNumber      string    `astm:"3"`
+	ExpirationDate time.Time `astm:"4"`
+}
+
+// Record structures //
+
+type Header struct {
+	SenderNameOrID  string    `astm:"5"`           // 6.5
+	SoftwareVersion string    `astm:"6"`           // 6.6
+	DateAndTime     time.Time `astm:"14,longdate"` // 6.14
+}
+type Patient struct {
+	LabAssignedPatientID string    `astm:"4"`   // 7.4
+	LastName             string    `astm:"6.1"` // 7.6.1
+	FirstName            string    `astm:"6.2"` // 7.6.2
+	DOB                  time.Time `astm:"8"`   // 7.8
+	Gender               string    `astm:"9"`   // 7.9
+}
+type Order struct {
+	SpecimenID                 string                          `astm:"3"`           // 8.4.3
+	InstrumentSpecimenIDs      []string                        `astm:"4.1"`         // 8.4.4
+	UniversalTestID            lis02a2.ExtendedUniversalTestID `astm:"5"`           // 8.4.5
+	Priority                   lis02a2.Priority                `astm:"6"`           // 8.4.6
+	RequestedOrderDateTime     time.Time                       `astm:"7,longdate"`  // 8.4.7
+	SpecimenCollectionDateTime time.Time                       `astm:"8,longdate"`  // 8.4.8
+	UserField1                 string                          `astm:"19"`          // 8.4.19
+	DateTimeResultsReported    time.Time                       `astm:"23,longdate"` // 8.4.23
+	ReportType                 lis02a2.ReportType              `astm:"26"`          // 8.4.26
+}
+
+// Result of a single reaction (e.g. 40) or the interpretation of the profile (e.g. A^NEG^NEG^ccee^K-^NEG)
+type Result struct {
+	UniversalTestID          lis02a2.ExtendedUniversalTestID `astm:"3"`           // 9.3
+	Value                    string                          `astm:"4"`           // 9.4
+	Units                    string                          `astm:"5"`           // 9.5
+	ResultStatus             lis02a2.ResultStatus            `astm:"9"`           // 9.9
+	OperatorIDPerformed      string                          `astm:"11.1"`        // 9.11
+	DateTimeTestStarted      time.Time                       `astm:"12,longdate"` // 9.12
+	InstrumentIdentification string                          `astm:"14"`          // 9.14
+	InstrumentName           string                          `astm:"15"`
+	InstrumentSerialNumber   string                          `astm:"16"`
+	OperatorIDReleased       string                          `astm:"17"`
+}
+
+// ReagentComment lists the reagents and the test card or plate used for a result
+type ReagentComment struct {
+	Reagents                    []Reagent `astm:"3"`
+	TypeOfTestMedia             string    `astm:"4.1"`
+	PlateOrIDCardBarcode        string    `astm:"4.2"`
+	LotNumberOfCassetteOrPlate  string    `astm:"4.3"`
+	ExpDateForIDCardOrPlate     time.Time `astm:"4.4"`
+	IDCardOrPlateRealWellNumber string    `astm:"4.5"`
+	Comment                     string    `astm:"5"`
+	FileName                    string    `astm:"6"`
+}
+
+// Message structures //
+
+type PatientResult struct {
+	Patient      Patient `astm:"P"`
+	OrderResults []OrderResult
+}
+type OrderResult struct {
+	Order        Order `astm:"O"`
+	ResultGroups []ResultGroup
+}
+type ResultGroup struct {
+	Result          Result           `astm:"R"`
+	ReagentComments []ReagentComment `astm:"C,optional"`
+}
+
+// Messages //
+
+type ResultMessage struct {
+	Header         Header `astm:"H"`
+	PatientResults []PatientResult
+	Terminator     lis02a2.Terminator `astm:"L"`
+}
@@ -0,0 +1,271 @@
+package lis02a2
+
+// Typed code values of the LIS02-A2 coded fields
+// Every code type has a Description of the code's meaning and an IsValid check for the codes defined by the standard
+// Unmarshal does not reject unknown codes, use IsValid (or the enum attribute in custom structures) to check them
+
+// Priority of an order (8.4.6)
+type Priority string
+
+const PriorityStat Priority = "S"
+const PriorityASAP Priority = "A"
+const PriorityRoutine Priority = "R"
+const PriorityCallback Priority = "C"
+const PriorityPreoperative Priority = "P"
+
+var priorityDescriptions = map[Priority]string{
+	PriorityStat:         "stat",
+	PriorityASAP:         "as soon as possible",
+	PriorityRoutine:      "routine",
+	PriorityCallback:     "callback",
+	PriorityPreoperative: "preoperative",
+}
+
+func (code Priority) Description() string {
+	return priorityDescriptions[code]
+}
+func (code Priority) IsValid() bool {
+	_, exists := priorityDescriptions[code]
+	return exists
+}
+
+// ActionCode of an order (8.4.12)
+type ActionCode string
+
+const ActionCodeCancel ActionCode = "C"
+const ActionCodeAdd ActionCode = "A"
+const ActionCodeNew ActionCode = "N"
+const ActionCodePending ActionCode = "P"
+const ActionCodeReserved ActionCode = "L"
+const ActionCodeInProcess ActionCode = "X"
+const ActionCodeQualityControl ActionCode = "Q"
+
+var actionCodeDescriptions = map[ActionCode]string{
+	ActionCodeCancel:         "cancel request for the battery or tests named",
+	ActionCodeAdd:            "add the requested tests or batteries to the existing specimen",
+	ActionCodeNew:            "new requests accompanying a new specimen",
+	ActionCodePending:        "pending specimen",
+	ActionCodeReserved:       "reserved",
+	ActionCodeInProcess:      "specimen or test already in process",
+	ActionCodeQualityControl: "treat specimen as a Q/C test specimen",
+}
+
+func (code ActionCode) Description() string {
+	return actionCodeDescriptions[code]
+}
+func (code ActionCode) IsValid() bool {
+	_, exists := actionCodeDescriptions[code]
+	return exists
+}
+
+// ReportType of an order (8.4.26)
+type ReportType string
+
+const ReportTypeOrder ReportType = "O"
+const ReportTypeCorrection ReportType = "C"
+const ReportTypePreliminary ReportType = "P"
+const ReportTypeFinal ReportType = "F"
+const ReportTypeCancelled ReportType = "X"
+const ReportTypeInstrumentPending ReportType = "I"
+const ReportTypeNoOrder ReportType = "Y"
+const ReportTypeNoPatient ReportType = "Z"
+const ReportTypeQueryResponse ReportType = "Q"
+
+var reportTypeDescriptions = map[ReportType]string{
+	ReportTypeOrder:             "order record, user asking that analysis be performed",
+	ReportTypeCorrection:        "correction of previously transmitted results",
+	ReportTypePreliminary:       "preliminary results",
+	ReportTypeFinal:             "final results",
+	ReportTypeCancelled:         "order cannot be done, order cancelled",
+	ReportTypeInstrumentPending: "in instrument pending",
+	ReportTypeNoOrder:           "no order on record for this test (response to query)",
+	ReportTypeNoPatient:         "no record of this patient (response to query)",
+	ReportTypeQueryResponse:     "response to query",
+}
+
+func (code ReportType) Description() string {
+	return reportTypeDescriptions[code]
+}
+func (code ReportType) IsValid() bool {
+	_, exists := reportTypeDescriptions[code]
+	return exists
+}
+
+// AbnormalFlag of a result (9.7)
+type AbnormalFlag string
+
+const AbnormalFlagLow AbnormalFlag = "L"
+const AbnormalFlagHigh AbnormalFlag = "H"
+const AbnormalFlagPanicLow AbnormalFlag = "LL"
+const AbnormalFlagPanicHigh AbnormalFlag = "HH"
+const AbnormalFlagBelowScale AbnormalFlag = "<"
+const AbnormalFlagAboveScale AbnormalFlag = ">"
+const AbnormalFlagNormal AbnormalFlag = "N"
+const AbnormalFlagAbnormal AbnormalFlag = "A"
+const AbnormalFlagChangeUp AbnormalFlag = "U"
+const AbnormalFlagChangeDown AbnormalFlag = "D"
+const AbnormalFlagBetter AbnormalFlag = "B"
+const AbnormalFlagWorse AbnormalFlag = "W"
+
+var abnormalFlagDescriptions = map[AbnormalFlag]string{
+	AbnormalFlagLow:        "below low normal",
+	AbnormalFlagHigh:       "above high normal",
+	AbnormalFlagPanicLow:   "below panic normal",
+	AbnormalFlagPanicHigh:  "above panic high",
+	AbnormalFlagBelowScale: "below absolute low, off low scale on an instrument",
+	AbnormalFlagAboveScale: "above absolute high, off high scale on an instrument",
+	AbnormalFlagNormal:     "normal",
+	AbnormalFlagAbnormal:   "abnormal",
+	AbnormalFlagChangeUp:   "significant change up",
+	AbnormalFlagChangeDown: "significant change down",
+	AbnormalFlagBetter:     "better",
+	AbnormalFlagWorse:      "worse",
+}
+
+func (code AbnormalFlag) Description() string {
+	return abnormalFlagDescriptions[code]
+}
+func (code AbnormalFlag) IsValid() bool {
+	_, exists := abnormalFlagDescriptions[code]
+	return exists
+}
+
+// NatureOfAbnormalityTesting of a result (9.8), the codes can be combined (e.g. AS)
+type NatureOfAbnormalityTesting string
+
+const NatureOfAbnormalityTestingAge NatureOfAbnormalityTesting = "A"
+const NatureOfAbnormalityTestingSex NatureOfAbnormalityTesting = "S"
+const NatureOfAbnormalityTestingRace NatureOfAbnormalityTesting = "R"
+const NatureOfAbnormalityTestingGeneric NatureOfAbnormalityTesting = "N"
+
+var natureOfAbnormalityTestingDescriptions = map[NatureOfAbnormalityTesting]string{
+	NatureOfAbnormalityTestingAge:     "age based population tested",
+	NatureOfAbnormalityTestingSex:     "sex based population tested",
+	NatureOfAbnormalityTestingRace:    "race based population tested",
+	NatureOfAbnormalityTestingGeneric: "generic normal range applied to all patient specimens",
+}
+
+// Description of a combined code lists the descriptions of each code
+func (code NatureOfAbnormalityTesting) Description() (result string) {
+	for _, single := range code {
+		description, exists := natureOfAbnormalityTestingDescriptions[NatureOfAbnormalityTesting(single)]
+		if !exists {
+			return ""
+		}
+		if result != "" {
+			result += ", "
+		}
+		result += description
+	}
+	return result
+}
+func (code NatureOfAbnormalityTesting) IsValid() bool {
+	return code.Description() != ""
+}
+
+// ResultStatus of a result (9.9)
+type ResultStatus string
+
+const ResultStatusCorrection ResultStatus = "C"
+const ResultStatusPreliminary ResultStatus = "P"
+const ResultStatusFinal ResultStatus = "F"
+const ResultStatusCannotBeDone ResultStatus = "X"
+const ResultStatusPending ResultStatus = "I"
+const ResultStatusPartial ResultStatus = "S"
+const ResultStatusMICLevel ResultStatus = "M"
+const ResultStatusPreviouslyTransmitted ResultStatus = "R"
+const ResultStatusNewOrder ResultStatus = "N"
+const ResultStatusQueryResponse ResultStatus = "Q"
+const ResultStatusVerified ResultStatus = "V"
+const ResultStatusWarning ResultStatus = "W"
+
+var resultStatusDescriptions = map[ResultStatus]string{
+	ResultStatusCorrection:            "correction of previously transmitted results",
+	ResultStatusPreliminary:           "preliminary results",
+	ResultStatusFinal:                 "final results",
+	ResultStatusCannotBeDone:          "order cannot be done",
+	ResultStatusPending:               "in instrument results pending",
+	ResultStatusPartial:               "partial results",
+	ResultStatusMICLevel:              "MIC level",
+	ResultStatusPreviouslyTransmitted: "this result was previously transmitted",
+	ResultStatusNewOrder:              "this result record contains necessary information to run a new order",
+	ResultStatusQueryResponse:         "this result is a response to an outstanding query",
+	ResultStatusVerified:              "operator verified/approved result",
+	ResultStatusWarning:               "warning: validity is questionable",
+}
+
+func (code ResultStatus) Description() string {
+	return resultStatusDescriptions[code]
+}
+func (code ResultStatus) IsValid() bool {
+	_, exists := resultStatusDescriptions[code]
+	return exists
+}
+
+// RequestInformationStatus of a query (11.13)
+type RequestInformationStatus string
+
+const RequestInformationStatusCorrection RequestInformationStatus = "C"
+const RequestInformationStatusPreliminary RequestInformationStatus = "P"
+const RequestInformationStatusFinal RequestInformationStatus = "F"
+const RequestInformationStatusCancelled RequestInformationStatus = "X"
+const RequestInformationStatusPending RequestInformationStatus = "I"
+const RequestInformationStatusPartial RequestInformationStatus = "S"
+const RequestInformationStatusMICLevel RequestInformationStatus = "M"
+const RequestInformationStatusPreviouslyTransmitted RequestInformationStatus = "R"
+const RequestInformationStatusAbort RequestInformationStatus = "A"
+const RequestInformationStatusNewOnly RequestInformationStatus = "N"
+const RequestInformationStatusOrdersOnly RequestInformationStatus = "O"
+const RequestInformationStatusDemographicsOnly RequestInformationStatus = "D"
+
+var requestInformationStatusDescriptions = map[RequestInformationStatus]string{
+	RequestInformationStatusCorrection:            "correction of previously transmitted results",
+	RequestInformationStatusPreliminary:           "preliminary results",
+	RequestInformationStatusFinal:                 "final results",
+	RequestInformationStatusCancelled:             "results cannot be done, request cancelled",
+	RequestInformationStatusPending:               "request results pending",
+	RequestInformationStatusPartial:               "request partial/unfinalized results",
+	RequestInformationStatusMICLevel:              "result is an MIC level",
+	RequestInformationStatusPreviouslyTransmitted: "result previously transmitted",
+	RequestInformationStatusAbort:                 "abort/cancel last request criteria",
+	RequestInformationStatusNewOnly:               "requesting new or edited results only",
+	RequestInformationStatusOrdersOnly:            "requesting test orders and demographics only (no results)",
+	RequestInformationStatusDemographicsOnly:      "requesting demographics only",
+}
+
+func (code RequestInformationStatus) Description() string {
+	return requestInformationStatusDescriptions[code]
+}
+func (code RequestInformationStatus) IsValid() bool {
+	_, exists := requestInformationStatusDescriptions[code]
+	return exists
+}
+
+// TerminatorCode of a message (12.3)
+type TerminatorCode string
+
+const TerminatorCodeNormal TerminatorCode = "N"
+const TerminatorCodeSenderAborted TerminatorCode = "T"
+const TerminatorCodeReceiverAborted TerminatorCode = "R"
+const TerminatorCodeUnknownError TerminatorCode = "E"
+const TerminatorCodeQueryError TerminatorCode = "Q"
+const TerminatorCodeNoInformation TerminatorCode = "I"
+const TerminatorCodeQueryProcessed TerminatorCode = "F"
+
+var terminatorCodeDescriptions = map[TerminatorCode]string{
+	TerminatorCodeNormal:          "normal termination",
+	TerminatorCodeSenderAborted:   "sender aborted",
+	TerminatorCodeReceiverAborted: "receiver requested abort",
+	TerminatorCodeUnknownError:    "unknown system error",
+	TerminatorCodeQueryError:      "error in last request for information",
+	TerminatorCodeNoInformation:   "no information available from last query",
+	TerminatorCodeQueryProcessed:  "last request for information processed",
+}
+
+func (code TerminatorCode) Description() string {
+	return terminatorCodeDescriptions[code]
+}
+func (code TerminatorCode) IsValid() bool {
+	_, exists := terminatorCodeDescriptions[code]
+	return exists
+}
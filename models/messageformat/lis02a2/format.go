@@ -82,13 +82,13 @@ type Order struct {
 	SpecimenID                   string                  `astm:"3"`           // 8.4.3
 	InstrumentSpecimenID         string                  `astm:"4"`           // 8.4.4
 	UniversalTestID              StandardUniversalTestID `astm:"5"`           // 8.4.5
-	Priority                     string                  `astm:"6"`           // 8.4.6
+	Priority                     Priority                `astm:"6"`           // 8.4.6
 	RequestedOrderDateTime       time.Time               `astm:"7,longdate"`  // 8.4.7
 	SpecimenCollectionDateTime   time.Time               `astm:"8,longdate"`  // 8.4.8
 	CollectionEndTime            time.Time               `astm:"9,longdate"`  // 8.4.9
 	CollectionVolume             string                  `astm:"10"`          // 8.4.10
 	CollectionID                 string                  `astm:"11"`          // 8.4.11
-	ActionCode                   string                  `astm:"12"`          // 8.4.12
+	ActionCode                   ActionCode              `astm:"12"`          // 8.4.12
 	DangerCode                   string                  `astm:"13"`          // 8.4.13
 	RelevantClinicalInformation  string                  `astm:"14"`          // 8.4.14
 	DateTimeSpecimenReceived     string                  `astm:"15"`          // 8.4.15
@@ -103,7 +103,7 @@ type Order struct {
 	DateTimeResultsReported      time.Time               `astm:"23,longdate"` // 8.4.23
 	InstrumentCharge             string                  `astm:"24"`          // 8.4.24
 	InstrumentSectionID          string                  `astm:"25"`          // 8.4.25
-	ReportType                   string                  `astm:"26"`          // 8.4.26
+	ReportType                   ReportType              `astm:"26"`          // 8.4.26
 	Reserved                     string                  `astm:"27"`          // 8.4.27
 	LocationOfSpecimenCollection string                  `astm:"28"`          // 8.4.28
 	NosocomialInfectionFlag      string                  `astm:"29"`          // 8.4.29
@@ -111,34 +111,34 @@ type Order struct {
 	SpecimenInstitution          string                  `astm:"31"`          // 8.4.31
 }
 type Result struct {
-	UniversalTestID                          ExtendedUniversalTestID `astm:"3"`           // 9.3
-	DataMeasurementValue                     string                  `astm:"4.1"`         // 9.4
-	InitialMeasurementValue                  string                  `astm:"4.2"`         // 9.4
-	MeasurementValueOfDevice                 string                  `astm:"4.3"`         // 9.4
-	Units                                    string                  `astm:"5"`           // 9.5
-	ReferenceRange                           string                  `astm:"6"`           // 9.6
-	ResultAbnormalFlag                       string                  `astm:"7"`           // 9.7
-	NatureOfAbnormalTesting                  string                  `astm:"8"`           // 9.8
-	ResultStatus                             string                  `astm:"9"`           // 9.9
-	DateOfChangeInInstrumentNormativeTesting time.Time               `astm:"10,longdate"` // 9.10
-	OperatorIDPerformed                      string                  `astm:"11.1"`        // 9.11
-	OperatorIDVerified                       string                  `astm:"11.2"`        // 9.11
-	DateTimeTestStarted                      time.Time               `astm:"12,longdate"` // 9.12
-	DateTimeCompleted                        time.Time               `astm:"13,longdate"` // 9.13
-	InstrumentIdentification                 string                  `astm:"14"`          // 9.14
+	UniversalTestID                          ExtendedUniversalTestID    `astm:"3"`           // 9.3
+	DataMeasurementValue                     string                     `astm:"4.1"`         // 9.4
+	InitialMeasurementValue                  string                     `astm:"4.2"`         // 9.4
+	MeasurementValueOfDevice                 string                     `astm:"4.3"`         // 9.4
+	Units                                    string                     `astm:"5"`           // 9.5
+	ReferenceRange                           string                     `astm:"6"`           // 9.6
+	ResultAbnormalFlag                       AbnormalFlag               `astm:"7"`           // 9.7
+	NatureOfAbnormalTesting                  NatureOfAbnormalityTesting `astm:"8"`           // 9.8
+	ResultStatus                             ResultStatus               `astm:"9"`           // 9.9
+	DateOfChangeInInstrumentNormativeTesting time.Time                  `astm:"10,longdate"` // 9.10
+	OperatorIDPerformed                      string                     `astm:"11.1"`        // 9.11
+	OperatorIDVerified                       string                     `astm:"11.2"`        // 9.11
+	DateTimeTestStarted                      time.Time                  `astm:"12,longdate"` // 9.12
+	DateTimeCompleted                        time.Time                  `astm:"13,longdate"` // 9.13
+	InstrumentIdentification                 string                     `astm:"14"`          // 9.14
 }
 type Query struct {
-	StartingRangeIDNumber           string `astm:"3"`  // 11.3
-	EndingRangeIDNumber             string `astm:"4"`  // 11.4
-	UniversalTestID                 string `astm:"5"`  // 11.5
-	NatureOfRequestTimeLimits       string `astm:"6"`  // 11.6
-	BeginningRequestResultsDateTime string `astm:"7"`  // 11.7
-	EndingRequestResultsDateTime    string `astm:"8"`  // 11.8
-	RequestingPhysicianName         string `astm:"9"`  // 11.9
-	RequestingPhysicianTelephone    string `astm:"10"` // 11.10
-	UserField1                      string `astm:"11"` // 11.11
-	UserField2                      string `astm:"12"` // 11.12
-	RequestInformationStatus        string `astm:"13"` // 11.13
+	StartingRangeIDNumber           string                   `astm:"3"`  // 11.3
+	EndingRangeIDNumber             string                   `astm:"4"`  // 11.4
+	UniversalTestID                 string                   `astm:"5"`  // 11.5
+	NatureOfRequestTimeLimits       string                   `astm:"6"`  // 11.6
+	BeginningRequestResultsDateTime string                   `astm:"7"`  // 11.7
+	EndingRequestResultsDateTime    string                   `astm:"8"`  // 11.8
+	RequestingPhysicianName         string                   `astm:"9"`  // 11.9
+	RequestingPhysicianTelephone    string                   `astm:"10"` // 11.10
+	UserField1                      string                   `astm:"11"` // 11.11
+	UserField2                      string                   `astm:"12"` // 11.12
+	RequestInformationStatus        RequestInformationStatus `astm:"13"` // 11.13
 }
 type Comment struct {
 	CommentSource string `astm:"3"` // 10.3
@@ -159,8 +159,30 @@ type Manufacturer struct {
 	F13 string `astm:"13"` // 14.13
 	F14 string `astm:"14"` // 14.14
 }
+type Scientific struct {
+	AnalyticalMethod             string    `astm:"3"`           // 13.3
+	Instrumentation              string    `astm:"4"`           // 13.4
+	Reagents                     string    `astm:"5"`           // 13.5
+	UnitsOfMeasure               string    `astm:"6"`           // 13.6
+	QualityControl               string    `astm:"7"`           // 13.7
+	SpecimenType                 string    `astm:"8.1"`         // 13.8
+	SpecimenSource               string    `astm:"8.2"`         // 13.8
+	Reserved                     string    `astm:"9"`           // 13.9
+	Container                    string    `astm:"10"`          // 13.10
+	SpecimenID                   string    `astm:"11"`          // 13.11
+	Analyte                      string    `astm:"12"`          // 13.12
+	Result                       string    `astm:"13"`          // 13.13
+	ResultUnits                  string    `astm:"14"`          // 13.14
+	CollectionDateTime           time.Time `astm:"15,longdate"` // 13.15
+	ResultDateTime               time.Time `astm:"16,longdate"` // 13.16
+	AnalyticalPreprocessingSteps string    `astm:"17"`          // 13.17
+	PatientDiagnosis             string    `astm:"18"`          // 13.18
+	PatientBirthdate             time.Time `astm:"19"`          // 13.19
+	PatientSex                   string    `astm:"20"`          // 13.20
+	PatientRace                  string    `astm:"21"`          // 13.21
+}
 type Terminator struct { //Hasta la vista...
-	TerminatorCode string `astm:"3"` // 12.3
+	TerminatorCode TerminatorCode `astm:"3"` // 12.3
 }
 
 // Message structures //
@@ -199,6 +221,16 @@ type QueryMessage struct {
 	Queries    []Query    `astm:"Q"`
 	Terminator Terminator `astm:"L"`
 }
+type ScientificMessage struct {
+	Header      Header       `astm:"H"`
+	Scientifics []Scientific `astm:"S"`
+	Terminator  Terminator   `astm:"L"`
+}
+type QueryResponseMessage struct {
+	Header        Header `astm:"H"`
+	PatientOrders []PatientOrder
+	Terminator    Terminator `astm:"L"`
+}
 type OrderMessage struct {
 	Header        Header `astm:"H"`
 	PatientOrders []PatientOrder
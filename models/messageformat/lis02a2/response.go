@@ -0,0 +1,38 @@
+package lis02a2
+
+// Query responses (11.1) answer a QueryMessage with the requested patient and order records
+// The terminator code tells the querying side whether the query was processed (12.3)
+
+// NewQueryResponseMessage creates the response to a processed query
+// Without any patient orders the terminator reports that no information is available
+func NewQueryResponseMessage(header Header, patientOrders []PatientOrder) QueryResponseMessage {
+	return QueryResponseMessage{
+		Header:        header,
+		PatientOrders: patientOrders,
+		Terminator:    queryResponseTerminator(len(patientOrders) > 0),
+	}
+}
+
+// NewStructuredQueryResponseMessage is the NewQueryResponseMessage of the fully structured variant
+func NewStructuredQueryResponseMessage(header StructuredHeader, patientOrders []StructuredPatientOrder) StructuredQueryResponseMessage {
+	return StructuredQueryResponseMessage{
+		Header:        header,
+		PatientOrders: patientOrders,
+		Terminator:    queryResponseTerminator(len(patientOrders) > 0),
+	}
+}
+
+// NewQueryErrorResponseMessage creates the response to a query that could not be processed
+func NewQueryErrorResponseMessage(header Header) QueryResponseMessage {
+	return QueryResponseMessage{
+		Header:     header,
+		Terminator: Terminator{TerminatorCode: TerminatorCodeQueryError},
+	}
+}
+
+func queryResponseTerminator(hasInformation bool) Terminator {
+	if hasInformation {
+		return Terminator{TerminatorCode: TerminatorCodeQueryProcessed}
+	}
+	return Terminator{TerminatorCode: TerminatorCodeNoInformation}
+}
@@ -0,0 +1,189 @@
+package lis02a2
+
+import (
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"time"
+)
+
+// Fully structured LIS02-A2 record and message format declarations
+// Composite fields are mapped to their components, dates to time.Time and repeatable fields to arrays
+// Records without composite fields (Result, Comment, Manufacturer, Scientific, Terminator) are shared with the flat declarations
+// The commented section numbers refer to the same document as the flat declarations in format.go
+
+// Record substructures //
+
+type PersonName struct { // 5.6.6
+	LastName   string `astm:"1"`
+	FirstName  string `astm:"2"`
+	MiddleName string `astm:"3"`
+	Suffix     string `astm:"4"`
+	Title      string `astm:"5"`
+}
+type Address struct { // 5.6.5
+	StreetAddress string `astm:"1"`
+	City          string `astm:"2"`
+	State         string `astm:"3"`
+	ZipCode       string `astm:"4"`
+	CountryCode   string `astm:"5"`
+}
+type PhysicianID struct { // 7.14
+	ID            string `astm:"1"`
+	LastName      string `astm:"2"`
+	FirstName     string `astm:"3"`
+	MiddleInitial string `astm:"4"`
+}
+type Quantity struct { // 7.17, 7.18, 8.4.10
+	Value astmmodels.Decimal `astm:"1"`
+	Units string             `astm:"2"`
+}
+type RangeID struct { // 11.3, 11.4
+	PatientID           string `astm:"1"` // also holds the ALL keyword
+	SpecimenID          string `astm:"2"`
+	ManufacturerDefined string `astm:"3"`
+}
+
+// Record structures //
+
+type StructuredHeader struct {
+	MessageControlID        string    `astm:"3"`           // 6.3
+	AccessPassword          string    `astm:"4"`           // 6.4
+	SenderNameOrID          string    `astm:"5"`           // 6.5
+	SenderStreetAddress     Address   `astm:"6"`           // 6.6
+	Reserved                string    `astm:"7"`           // 6.7
+	SenderTelephone         []string  `astm:"8"`           // 6.8
+	CharacteristicsOfSender string    `astm:"9"`           // 6.9
+	ReceiverID              string    `astm:"10"`          // 6.10
+	Comment                 string    `astm:"11"`          // 6.11
+	ProcessingID            string    `astm:"12"`          // 6.12
+	Version                 string    `astm:"13"`          // 6.13
+	DateAndTime             time.Time `astm:"14,longdate"` // 6.14
+}
+type StructuredPatient struct {
+	PracticeAssignedPatientID          string        `astm:"3"`    // 7.3
+	LabAssignedPatientID               string        `astm:"4"`    // 7.4
+	ID3                                string        `astm:"5"`    // 7.5
+	Name                               PersonName    `astm:"6"`    // 7.6
+	MothersMaidenName                  string        `astm:"7"`    // 7.7
+	DOB                                time.Time     `astm:"8"`    // 7.8
+	Gender                             string        `astm:"9"`    // 7.9
+	Race                               string        `astm:"10"`   // 7.10
+	Address                            Address       `astm:"11"`   // 7.11
+	Reserved                           string        `astm:"12"`   // 7.12
+	Telephone                          []string      `astm:"13"`   // 7.13
+	AttendingPhysicianID               []PhysicianID `astm:"14"`   // 7.14
+	SpecialField1                      string        `astm:"15"`   // 7.15
+	SpecialField2                      string        `astm:"16"`   // 7.16
+	Height                             Quantity      `astm:"17"`   // 7.17
+	Weight                             Quantity      `astm:"18"`   // 7.18
+	SuspectedDiagnosis                 []string      `astm:"19"`   // 7.19
+	ActiveMedication                   []string      `astm:"20"`   // 7.20
+	Diet                               string        `astm:"21"`   // 7.21
+	PracticeField1                     string        `astm:"22"`   // 7.22
+	PracticeField2                     string        `astm:"23"`   // 7.23
+	AdmissionDate                      time.Time     `astm:"24.1"` // 7.24
+	DischargeDate                      time.Time     `astm:"24.2"` // 7.24
+	AdmissionStatus                    string        `astm:"25"`   // 7.25
+	Location                           string        `astm:"26"`   // 7.26
+	NatureOfAlternativeDiagnosticCodes string        `astm:"27"`   // 7.27
+	AlternativeDiagnosticCodes         []string      `astm:"28"`   // 7.28
+	Religion                           string        `astm:"29"`   // 7.29
+	MaritalStatus                      string        `astm:"30"`   // 7.30
+	IsolationStatus                    []string      `astm:"31"`   // 7.31
+	Language                           string        `astm:"32"`   // 7.32
+	HospitalService                    string        `astm:"33"`   // 7.33
+	HospitalInstitution                string        `astm:"34"`   // 7.34
+	DosageCategory                     string        `astm:"35"`   // 7.35
+}
+type StructuredOrder struct {
+	SpecimenID                   string                    `astm:"3"`           // 8.4.3
+	InstrumentSpecimenID         string                    `astm:"4"`           // 8.4.4
+	UniversalTestIDs             []StandardUniversalTestID `astm:"5"`           // 8.4.5
+	Priority                     Priority                  `astm:"6"`           // 8.4.6
+	RequestedOrderDateTime       time.Time                 `astm:"7,longdate"`  // 8.4.7
+	SpecimenCollectionDateTime   time.Time                 `astm:"8,longdate"`  // 8.4.8
+	CollectionEndTime            time.Time                 `astm:"9,longdate"`  // 8.4.9
+	CollectionVolume             Quantity                  `astm:"10"`          // 8.4.10
+	CollectorID                  string                    `astm:"11"`          // 8.4.11
+	ActionCode                   ActionCode                `astm:"12"`          // 8.4.12
+	DangerCode                   string                    `astm:"13"`          // 8.4.13
+	RelevantClinicalInformation  string                    `astm:"14"`          // 8.4.14
+	DateTimeSpecimenReceived     time.Time                 `astm:"15,longdate"` // 8.4.15
+	SpecimenType                 string                    `astm:"16.1"`        // 8.4.16
+	SpecimenSource               string                    `astm:"16.2"`        // 8.4.16
+	OrderingPhysician            PersonName                `astm:"17"`          // 8.4.17
+	PhysicianTelephone           []string                  `astm:"18"`          // 8.4.18
+	UserField1                   string                    `astm:"19"`          // 8.4.19
+	UserField2                   string                    `astm:"20"`          // 8.4.20
+	LaboratoryField1             string                    `astm:"21"`          // 8.4.21
+	LaboratoryField2             string                    `astm:"22"`          // 8.4.22
+	DateTimeResultsReported      time.Time                 `astm:"23,longdate"` // 8.4.23
+	InstrumentCharge             string                    `astm:"24"`          // 8.4.24
+	InstrumentSectionID          string                    `astm:"25"`          // 8.4.25
+	ReportType                   ReportType                `astm:"26"`          // 8.4.26
+	Reserved                     string                    `astm:"27"`          // 8.4.27
+	LocationOfSpecimenCollection string                    `astm:"28"`          // 8.4.28
+	NosocomialInfectionFlag      string                    `astm:"29"`          // 8.4.29
+	SpecimenService              string                    `astm:"30"`          // 8.4.30
+	SpecimenInstitution          string                    `astm:"31"`          // 8.4.31
+}
+type StructuredQuery struct {
+	StartingRangeIDNumber           RangeID                   `astm:"3"`          // 11.3
+	EndingRangeIDNumber             RangeID                   `astm:"4"`          // 11.4
+	UniversalTestIDs                []StandardUniversalTestID `astm:"5"`          // 11.5
+	NatureOfRequestTimeLimits       string                    `astm:"6"`          // 11.6
+	BeginningRequestResultsDateTime time.Time                 `astm:"7,longdate"` // 11.7
+	EndingRequestResultsDateTime    time.Time                 `astm:"8,longdate"` // 11.8
+	RequestingPhysicianName         PersonName                `astm:"9"`          // 11.9
+	RequestingPhysicianTelephone    []string                  `astm:"10"`         // 11.10
+	UserField1                      string                    `astm:"11"`         // 11.11
+	UserField2                      string                    `astm:"12"`         // 11.12
+	RequestInformationStatus        RequestInformationStatus  `astm:"13"`         // 11.13
+}
+
+// Message structures //
+
+type StructuredPatientGroup struct {
+	Patient     StructuredPatient `astm:"P"`
+	Comments    []Comment         `astm:"C,optional"`
+	OrderGroups []StructuredOrderGroup
+}
+type StructuredOrderGroup struct {
+	Order        StructuredOrder `astm:"O"`
+	ResultGroups []ResultGroup
+}
+type StructuredPatientOrder struct {
+	Patient StructuredPatient `astm:"P"`
+	Orders  []StructuredOrder `astm:"O"`
+}
+
+// Messages //
+
+type StructuredResultMessage struct {
+	Header        StructuredHeader `astm:"H"`
+	Manufacturer  Manufacturer     `astm:"M,optional"`
+	PatientGroups []StructuredPatientGroup
+	Terminator    Terminator `astm:"L"`
+}
+type StructuredResultMultiMessage struct {
+	ResultMessages []StructuredResultMessage
+}
+type StructuredQueryMessage struct {
+	Header     StructuredHeader  `astm:"H"`
+	Queries    []StructuredQuery `astm:"Q"`
+	Terminator Terminator        `astm:"L"`
+}
+type StructuredScientificMessage struct {
+	Header      StructuredHeader `astm:"H"`
+	Scientifics []Scientific     `astm:"S"`
+	Terminator  Terminator       `astm:"L"`
+}
+type StructuredQueryResponseMessage struct {
+	Header        StructuredHeader `astm:"H"`
+	PatientOrders []StructuredPatientOrder
+	Terminator    Terminator `astm:"L"`
+}
+type StructuredOrderMessage struct {
+	Header        StructuredHeader `astm:"H"`
+	PatientOrders []StructuredPatientOrder
+	Terminator    Terminator `astm:"L"`
+}
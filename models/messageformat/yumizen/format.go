@@ -0,0 +1,94 @@
+package yumizen
+
+import (
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/blutspende/go-astm/v3/models/messageformat/lis02a2"
+	"time"
+)
+
+// Horiba Yumizen H550 record and message format declarations
+// The declarations capture the fields used by the analyzer, see examples/yumizen
+// Histograms, matrices and reagent traceability are sent in manufacturer records, told apart by their subname
+// The manufacturer records are numbered across these types, unmarshal them with EnforceSequenceNumberCheck disabled
+// The analyzer doubles the repeat delimiter (H|\\^&), marshal with the repeat delimiter set to `\\` to send the same
+// The commented section numbers refer to the LIS02-A2 standard (see the lis02a2 package)
+// Fields without a section number are manufacturer specific
+
+// Record substructures //
+
+type ReagentTraceability struct {
+	LotNumber      string    `astm:"1"`
+	UsedAtDateTime time.Time `astm:"2,longdate"`
+	ExpirationDate time.Time `astm:"3"`
+}
+
+// Record structures //
+
+type Header struct {
+	InstrumentModel        string    `astm:"5.1"`         // 6.5
+	InstrumentSerialNumber string    `astm:"5.2"`         // 6.5
+	SoftwareVersion        string    `astm:"5.3"`         // 6.5
+	ProcessingID           string    `astm:"12"`          // 6.12
+	Version                string    `astm:"13"`          // 6.13
+	DateAndTime            time.Time `astm:"14,longdate"` // 6.14
+}
+type Order struct {
+	SpecimenID             string                          `astm:"3"`          // 8.4.3
+	UniversalTestID        lis02a2.StandardUniversalTestID `astm:"5"`          // 8.4.5
+	Priority               lis02a2.Priority                `astm:"6"`          // 8.4.6
+	RequestedOrderDateTime time.Time                       `astm:"7,longdate"` // 8.4.7
+	SpecimenType           string                          `astm:"16.1"`       // 8.4.16
+	ControlLevel           string                          `astm:"16.3"`       // 8.4.16
+	ReportType             lis02a2.ReportType              `astm:"26"`         // 8.4.26
+}
+
+// Stream is a histogram or matrix encoded as a compressed base64 stream of little endian floats
+type Stream struct {
+	Type            string `astm:"3"`
+	Channel         string `astm:"4"`
+	Name            string `astm:"5"`
+	Stream1Encoding string `astm:"6.1"`
+	Stream1         string `astm:"6.2"`
+	Stream2Encoding string `astm:"7.1"`
+	Stream2         string `astm:"7.2"`
+}
+
+// Traceability lists the reagents used for the results
+type Traceability struct {
+	Type         string                `astm:"3"`
+	ReagentNames []string              `astm:"4"`
+	Reagents     []ReagentTraceability `astm:"5"`
+}
+type Result struct {
+	TestCode            string                      `astm:"3.4"`         // 9.3
+	LOINCCode           string                      `astm:"3.5"`         // 9.3
+	Value               astmmodels.MeasurementValue `astm:"4"`           // 9.4
+	Units               string                      `astm:"5"`           // 9.5
+	ReferenceRange      string                      `astm:"6.1"`         // 9.6
+	ReferenceRangeType  string                      `astm:"6.2"`         // 9.6
+	ResultAbnormalFlag  lis02a2.AbnormalFlag        `astm:"7"`           // 9.7
+	ResultStatus        lis02a2.ResultStatus        `astm:"9"`           // 9.9
+	OperatorIDPerformed string                      `astm:"11.1"`        // 9.11
+	OperatorRole        string                      `astm:"11.3"`        // 9.11
+	DateTimeTestStarted time.Time                   `astm:"12,longdate"` // 9.12
+}
+
+// Message structures //
+
+type PatientResult struct {
+	Patient      lis02a2.Patient   `astm:"P"`
+	Comments     []lis02a2.Comment `astm:"C,optional"`
+	Order        Order             `astm:"O"`
+	Histograms   []Stream          `astm:"M,subname:HISTOGRAM,optional"`
+	Matrices     []Stream          `astm:"M,subname:MATRIX,optional"`
+	Traceability Traceability      `astm:"M,subname:REAGENT,optional"`
+	Results      []Result          `astm:"R"`
+}
+
+// Messages //
+
+type ResultMessage struct {
+	Header         Header `astm:"H"`
+	PatientResults []PatientResult
+	Terminator     lis02a2.Terminator `astm:"L"`
+}
@@ -2,13 +2,15 @@ package models
 
 // Annotation types for ASTM fields and structures
 type AstmFieldAnnotation struct {
-	Raw            string
-	FieldPos       int
-	IsArray        bool
-	IsComponent    bool
-	ComponentPos   int
-	IsSubstructure bool
-	Attributes     map[string]string
+	Raw             string
+	FieldPos        int
+	IsArray         bool
+	IsComponent     bool
+	ComponentPos    int
+	IsSubComponent  bool
+	SubComponentPos int
+	IsSubstructure  bool
+	Attributes      map[string]string
 }
 type AstmStructAnnotation struct {
 	Raw         string
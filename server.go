@@ -0,0 +1,100 @@
+package astm
+
+import (
+	"context"
+	"github.com/blutspende/bloodlab-common/messagetype"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"net"
+	"sync"
+)
+
+// Handler is called with every complete message received on a connection
+// Messages of the same connection are handled one after the other
+// Messages arriving while the handler runs are queued, so the handler can reply while the instrument keeps sending
+type Handler func(ctx context.Context, session *Session, messageData []byte, messageType messagetype.MessageType)
+
+type Server struct {
+	handler Handler
+	config  astmmodels.ServerConfiguration
+}
+
+func NewServer(handler Handler, configuration ...astmmodels.ServerConfiguration) *Server {
+	// Load configuration
+	config := astmmodels.DefaultServerConfiguration
+	if len(configuration) > 0 {
+		config = configuration[0]
+	}
+	return &Server{
+		handler: handler,
+		config:  config,
+	}
+}
+
+func (s *Server) ListenAndServe(ctx context.Context, address string) (err error) {
+	// Open the TCP listener
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+func (s *Server) Serve(ctx context.Context, listener net.Listener) (err error) {
+	// On exit stop accepting, stop all connections and wait for them to finish
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		listener.Close()
+		wg.Wait()
+	}()
+	// Closing the listener unblocks Accept when the context is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		listener.Close()
+	})
+	defer stop()
+
+	// Connection slots (nil means unlimited)
+	var slots chan struct{}
+	if s.config.MaxConnections > 0 {
+		slots = make(chan struct{}, s.config.MaxConnections)
+	}
+
+	for {
+		// Wait for a free slot before accepting the next connection
+		if slots != nil {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		conn, err := listener.Accept()
+		if err != nil {
+			// A cancelled context is a graceful shutdown, anything else is an error
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveConnection(ctx, conn)
+			if slots != nil {
+				<-slots
+			}
+		}()
+	}
+}
+
+func (s *Server) serveConnection(ctx context.Context, conn net.Conn) {
+	// Set up the session with the connection specific configuration
+	config := s.config.Configuration
+	if s.config.ConfigurationProvider != nil {
+		config = s.config.ConfigurationProvider(conn.RemoteAddr())
+	}
+	session := newSession(conn, config, s.config.Transmission, s.config.MessageQueueSize)
+	// Serve the connection until it is lost or the server shuts down
+	session.serve(ctx, conn, s.handler)
+}
@@ -0,0 +1,84 @@
+package astm
+
+import (
+	"context"
+	"github.com/blutspende/bloodlab-common/messagetype"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/blutspende/go-astm/v3/transmission"
+	"net"
+)
+
+// Session is one established link to an instrument, used to reply or send on the same connection
+type Session struct {
+	remoteAddress net.Addr
+	config        astmmodels.Configuration
+	connection    *transmission.Connection
+}
+
+func newSession(conn net.Conn, config astmmodels.Configuration, transmissionConfig astmmodels.TransmissionConfiguration, messageQueueSize int) *Session {
+	// The received messages are queued by the connection, so a reply of the handler is not blocked by the next message of the instrument
+	transmissionConfig.ReceiveQueueSize = max(messageQueueSize, 1)
+	return &Session{
+		remoteAddress: conn.RemoteAddr(),
+		config:        config,
+		connection:    transmission.NewConnection(conn, transmissionConfig),
+	}
+}
+
+func (s *Session) RemoteAddress() net.Addr {
+	return s.remoteAddress
+}
+
+func (s *Session) Configuration() astmmodels.Configuration {
+	return s.config
+}
+
+func (s *Session) Send(ctx context.Context, lines [][]byte) (err error) {
+	// Frame and transmit the already marshalled lines
+	return s.connection.Send(ctx, lines)
+}
+
+func (s *Session) Reply(ctx context.Context, sourceStruct interface{}) (err error) {
+	// Marshal the message with the configuration of the session
+	lines, err := Marshal(sourceStruct, s.config)
+	if err != nil {
+		return err
+	}
+	// Transmit the message and return the delivery status
+	return s.Send(ctx, lines)
+}
+
+func (s *Session) serve(ctx context.Context, conn net.Conn, handler Handler) {
+	// Run the protocol until the connection is lost or the context is cancelled
+	serveCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	runDone := make(chan struct{})
+	go func() {
+		defer close(runDone)
+		_ = s.connection.Run(ctx)
+		cancel()
+	}()
+	defer func() {
+		cancel()
+		conn.Close()
+		<-runDone
+	}()
+
+	// Hand over the received messages one after the other,
+	// the messages queued before the connection was lost are still handed over
+	for {
+		messageData, err := s.connection.Receive(serveCtx)
+		if err != nil {
+			return
+		}
+		// Without a handler the messages are acknowledged and dropped
+		if handler == nil {
+			continue
+		}
+		messageType, err := IdentifyMessage(messageData, s.config)
+		if err != nil {
+			messageType = messagetype.Unidentified
+		}
+		handler(ctx, s, messageData, messageType)
+	}
+}
@@ -0,0 +1,396 @@
+package transmission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/blutspende/go-astm/v3/constants"
+	"github.com/blutspende/go-astm/v3/enums/role"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/functions"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"io"
+	"time"
+)
+
+// Connection runs the LIS01-A2 establishment, transfer and termination phases over a byte stream
+// Run has to be active for Send and Receive to make progress, it is the only reader of the stream
+// Received messages are handed over one at a time, without a receive queue Receive has to be drained continuously:
+// until a received message is taken, Run waits for it and every later Send is blocked
+// With a receive queue Run does not wait, but answers enquiries with NAK (receiver busy) while the queue is full
+type Connection struct {
+	readWriter io.ReadWriter
+	config     astmmodels.TransmissionConfiguration
+	input      chan byte
+	readErr    error
+	requests   chan sendRequest
+	messages   chan []byte
+	done       chan struct{}
+}
+
+type sendRequest struct {
+	ctx     context.Context
+	records [][]byte
+	result  chan error
+}
+
+func NewConnection(readWriter io.ReadWriter, configuration ...astmmodels.TransmissionConfiguration) *Connection {
+	// Load configuration
+	config := astmmodels.DefaultTransmissionConfiguration
+	if len(configuration) > 0 {
+		config = configuration[0]
+	}
+	if config.Clock == nil {
+		config.Clock = astmmodels.SystemClock{}
+	}
+	// Set up the connection
+	return &Connection{
+		readWriter: readWriter,
+		config:     config,
+		input:      make(chan byte, 1024),
+		requests:   make(chan sendRequest),
+		messages:   make(chan []byte, max(config.ReceiveQueueSize, 0)),
+		done:       make(chan struct{}),
+	}
+}
+
+func (c *Connection) Run(ctx context.Context) (err error) {
+	// Start reading the stream and release the waiting Send and Receive calls at the end
+	go c.read()
+	defer close(c.done)
+
+	// The line is in neutral state between the transmissions
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case b, ok := <-c.input:
+			if !ok {
+				return fmt.Errorf("%w: %v", errmsg.ErrTransmissionConnectionClosed, c.readErr)
+			}
+			// Only an enquiry is meaningful in neutral state, everything else is line noise
+			if b == constants.ENQ {
+				err = c.receive(ctx)
+				if c.isFatal(ctx, err) {
+					return err
+				}
+			}
+		case request := <-c.requests:
+			// The transmission can be cancelled by the caller as well as by the connection
+			sendCtx, cancel := context.WithCancel(ctx)
+			stop := context.AfterFunc(request.ctx, cancel)
+			err = c.send(sendCtx, request.records)
+			stop()
+			cancel()
+			request.result <- err
+			if c.isFatal(ctx, err) {
+				return err
+			}
+		}
+	}
+}
+
+func (c *Connection) Send(ctx context.Context, records [][]byte) (err error) {
+	// Hand over the records to the running connection
+	request := sendRequest{ctx: ctx, records: records, result: make(chan error, 1)}
+	select {
+	case c.requests <- request:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return errmsg.ErrTransmissionConnectionClosed
+	}
+	// Wait for the delivery status
+	select {
+	case err = <-request.result:
+		return err
+	case <-c.done:
+		// The result is always provided before the connection is closed
+		select {
+		case err = <-request.result:
+			return err
+		default:
+			return errmsg.ErrTransmissionConnectionClosed
+		}
+	}
+}
+
+func (c *Connection) Receive(ctx context.Context) (messageData []byte, err error) {
+	// Wait for the next complete message, without a receive queue Run is blocked until it is taken
+	select {
+	case messageData = <-c.messages:
+		return messageData, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.done:
+		// Queued messages received before the end are still handed over
+		select {
+		case messageData = <-c.messages:
+			return messageData, nil
+		default:
+			return nil, errmsg.ErrTransmissionConnectionClosed
+		}
+	}
+}
+
+func (c *Connection) read() {
+	buffer := make([]byte, 1024)
+	for {
+		n, err := c.readWriter.Read(buffer)
+		for _, b := range buffer[:n] {
+			select {
+			case c.input <- b:
+			case <-c.done:
+				return
+			}
+		}
+		if err != nil {
+			// The error is saved before closing, so it is visible to the receiving side
+			c.readErr = err
+			close(c.input)
+			return
+		}
+	}
+}
+
+func (c *Connection) send(ctx context.Context, records [][]byte) (err error) {
+	// Split the records into frames
+	frames, err := functions.BuildFrames(records, 1)
+	if err != nil {
+		return err
+	}
+	// Establishment phase
+	err = c.establish(ctx)
+	if err != nil {
+		return err
+	}
+	// Transfer phase
+	for _, frame := range frames {
+		err = c.transfer(ctx, frame)
+		if err != nil {
+			// Terminate the transmission unless the connection itself is lost
+			if !errors.Is(err, errmsg.ErrTransmissionConnectionClosed) {
+				_ = c.write(constants.EOT)
+			}
+			return err
+		}
+	}
+	// Termination phase
+	return c.write(constants.EOT)
+}
+
+func (c *Connection) establish(ctx context.Context) (err error) {
+	for attempt := 1; ; attempt++ {
+		// Request the line
+		err = c.write(constants.ENQ)
+		if err != nil {
+			return err
+		}
+		reply, err := c.reply(ctx)
+		if err != nil {
+			if errors.Is(err, errmsg.ErrTransmissionTimeout) {
+				_ = c.write(constants.EOT)
+			}
+			return err
+		}
+		switch reply {
+		case constants.ACK:
+			return nil
+		case constants.NAK:
+			// The receiver is busy, wait before the next enquiry
+			if attempt >= c.config.MaxRetransmissions {
+				return errmsg.ErrTransmissionReceiverBusy
+			}
+			err = c.idle(ctx, c.config.BusyRetryDelay)
+		case constants.ENQ:
+			// Contention: the instrument has priority and retries after a short delay,
+			// the computer system yields and receives the instrument's message first
+			if attempt >= c.config.MaxRetransmissions {
+				return errmsg.ErrTransmissionReceiverBusy
+			}
+			if c.config.Role == role.Instrument {
+				err = c.idle(ctx, c.config.InstrumentContentionDelay)
+			} else {
+				err = c.idle(ctx, c.config.ComputerContentionDelay)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Connection) transfer(ctx context.Context, frame []byte) (err error) {
+	for attempt := 1; ; attempt++ {
+		// Send the frame
+		err = c.write(frame...)
+		if err != nil {
+			return err
+		}
+		reply, err := c.reply(ctx)
+		if err != nil {
+			return err
+		}
+		// EOT is a receiver interrupt request, the frame itself is accepted
+		if reply == constants.ACK || reply == constants.EOT {
+			return nil
+		}
+		// Anything else counts as a failed attempt and the frame is retransmitted
+		if attempt >= c.config.MaxRetransmissions {
+			return errmsg.ErrTransmissionMaxRetransmissionsReached
+		}
+	}
+}
+
+func (c *Connection) reply(ctx context.Context) (reply byte, err error) {
+	// Wait for a reply character, everything else is ignored until the timer expires
+	timer := c.config.Clock.After(c.config.ReplyTimeout)
+	for {
+		reply, err = c.next(ctx, timer)
+		if err != nil {
+			return 0, err
+		}
+		switch reply {
+		case constants.ACK, constants.NAK, constants.ENQ, constants.EOT:
+			return reply, nil
+		}
+	}
+}
+
+func (c *Connection) idle(ctx context.Context, duration time.Duration) (err error) {
+	// Stay in neutral state, but serve a transmission of the other side if it starts one
+	timer := c.config.Clock.After(duration)
+	for {
+		b, err := c.next(ctx, timer)
+		if errors.Is(err, errmsg.ErrTransmissionTimeout) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if b == constants.ENQ {
+			// An interrupted incoming transmission does not affect the pending outgoing one
+			err = c.receive(ctx)
+			if errors.Is(err, errmsg.ErrTransmissionTimeout) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (c *Connection) receive(ctx context.Context) (err error) {
+	// Refuse the line while the receive queue is full, the sender retries later
+	if c.config.ReceiveQueueSize > 0 && len(c.messages) >= cap(c.messages) {
+		return c.write(constants.NAK)
+	}
+	// Accept the line
+	err = c.write(constants.ACK)
+	if err != nil {
+		return err
+	}
+
+	expectedFrameNumber := 1
+	accepted := 0
+	complete := false
+	var messageData []byte
+	// Every frame or the end of transmission has to arrive in time, otherwise the line returns to neutral
+	// (only acknowledged frames restart the timer, so line noise does not keep the transfer alive)
+	timer := c.config.Clock.After(c.config.ReceiveTimeout)
+	for {
+		b, err := c.next(ctx, timer)
+		if err != nil {
+			return err
+		}
+		switch b {
+		case constants.EOT:
+			// Only complete messages are delivered, partial ones are discarded
+			if complete && len(messageData) > 0 {
+				select {
+				case c.messages <- messageData:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		case constants.STX:
+			frame, err := c.readFrame(ctx, timer)
+			if err != nil {
+				return err
+			}
+			frameNumber, text, isLast, err := functions.ParseFrame(frame)
+			acknowledged := false
+			switch {
+			case err != nil:
+				// Corrupted frame, the sender has to repeat it
+				err = c.write(constants.NAK)
+			case accepted > 0 && frameNumber == (expectedFrameNumber+constants.FrameNumberModulo-1)%constants.FrameNumberModulo:
+				// Repeated frame (the previous acknowledgement was lost), accept without using it again
+				acknowledged = true
+				err = c.write(constants.ACK)
+			case frameNumber != expectedFrameNumber:
+				err = c.write(constants.NAK)
+			default:
+				messageData = append(messageData, text...)
+				complete = isLast
+				accepted++
+				expectedFrameNumber = (expectedFrameNumber + 1) % constants.FrameNumberModulo
+				acknowledged = true
+				err = c.write(constants.ACK)
+			}
+			if err != nil {
+				return err
+			}
+			if acknowledged {
+				timer = c.config.Clock.After(c.config.ReceiveTimeout)
+			}
+		}
+		// Any other character outside of a frame is ignored
+	}
+}
+
+func (c *Connection) readFrame(ctx context.Context, timer <-chan time.Time) (frame []byte, err error) {
+	// Read until the frame terminator, then the checksum and the trailer (<C1><C2><CR><LF>)
+	frame = []byte{constants.STX}
+	trailer := -1
+	for trailer != 0 {
+		b, err := c.next(ctx, timer)
+		if err != nil {
+			return nil, err
+		}
+		frame = append(frame, b)
+		if trailer > 0 {
+			trailer--
+		} else if b == constants.ETX || b == constants.ETB {
+			trailer = 4
+		}
+	}
+	return frame, nil
+}
+
+func (c *Connection) next(ctx context.Context, timer <-chan time.Time) (b byte, err error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case b, ok := <-c.input:
+		if !ok {
+			return 0, fmt.Errorf("%w: %v", errmsg.ErrTransmissionConnectionClosed, c.readErr)
+		}
+		return b, nil
+	case <-timer:
+		return 0, errmsg.ErrTransmissionTimeout
+	}
+}
+
+func (c *Connection) write(data ...byte) (err error) {
+	_, err = c.readWriter.Write(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errmsg.ErrTransmissionConnectionClosed, err)
+	}
+	return nil
+}
+
+func (c *Connection) isFatal(ctx context.Context, err error) bool {
+	// Only the loss of the connection or the cancellation of the run ends the connection
+	return errors.Is(err, errmsg.ErrTransmissionConnectionClosed) || ctx.Err() != nil
+}
@@ -0,0 +1,394 @@
+package transmission
+
+import (
+	"context"
+	"github.com/blutspende/go-astm/v3/enums/role"
+	"github.com/blutspende/go-astm/v3/errmsg"
+	"github.com/blutspende/go-astm/v3/functions"
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Fake clock: every timer is published to the test, which decides when it fires
+type fakeTimer struct {
+	duration time.Duration
+	fire     chan time.Time
+}
+type fakeClock struct {
+	timers chan fakeTimer
+}
+
+func (f *fakeClock) After(duration time.Duration) <-chan time.Time {
+	timer := fakeTimer{duration: duration, fire: make(chan time.Time, 1)}
+	f.timers <- timer
+	return timer.fire
+}
+
+// Fire the n-th upcoming timer with the given duration (the skipped ones are abandoned timers)
+func (f *fakeClock) fire(t *testing.T, duration time.Duration, occurrence int) {
+	for {
+		select {
+		case timer := <-f.timers:
+			if timer.duration != duration {
+				continue
+			}
+			occurrence--
+			if occurrence == 0 {
+				timer.fire <- time.Now()
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no timer with duration %s", duration)
+		}
+	}
+}
+
+// Test setup: a running connection and the peer side of an in-memory pipe
+func setup(t *testing.T, connectionRole string) (connection *Connection, peer net.Conn, clock *fakeClock) {
+	local, peer := net.Pipe()
+	clock = &fakeClock{timers: make(chan fakeTimer, 1024)}
+	config := astmmodels.DefaultTransmissionConfiguration
+	config.Role = connectionRole
+	config.Clock = clock
+	connection = NewConnection(local, config)
+	ctx, cancel := context.WithCancel(context.Background())
+	go connection.Run(ctx)
+	t.Cleanup(func() {
+		cancel()
+		peer.Close()
+		local.Close()
+	})
+	return connection, peer, clock
+}
+
+// Peer helpers
+func expectByte(t *testing.T, peer net.Conn, expected byte) {
+	buffer := make([]byte, 1)
+	peer.SetReadDeadline(time.Now().Add(time.Second))
+	_, err := peer.Read(buffer)
+	require.Nil(t, err)
+	require.Equal(t, expected, buffer[0])
+}
+func readFrame(t *testing.T, peer net.Conn) []byte {
+	var frame []byte
+	buffer := make([]byte, 1)
+	peer.SetReadDeadline(time.Now().Add(time.Second))
+	for !strings.HasSuffix(string(frame), "\r\n") || len(frame) < 7 {
+		_, err := peer.Read(buffer)
+		require.Nil(t, err)
+		frame = append(frame, buffer[0])
+	}
+	return frame
+}
+func write(t *testing.T, peer net.Conn, data ...byte) {
+	peer.SetWriteDeadline(time.Now().Add(time.Second))
+	_, err := peer.Write(data)
+	require.Nil(t, err)
+}
+func sendAsync(connection *Connection, records ...string) chan error {
+	result := make(chan error, 1)
+	input := make([][]byte, len(records))
+	for i, record := range records {
+		input[i] = []byte(record)
+	}
+	go func() {
+		result <- connection.Send(context.Background(), input)
+	}()
+	return result
+}
+func receiveAsync(connection *Connection) chan []byte {
+	result := make(chan []byte, 1)
+	go func() {
+		messageData, _ := connection.Receive(context.Background())
+		result <- messageData
+	}()
+	return result
+}
+func frames(records ...string) [][]byte {
+	input := make([][]byte, len(records))
+	for i, record := range records {
+		input[i] = []byte(record)
+	}
+	result, _ := functions.BuildFrames(input, 1)
+	return result
+}
+func awaitError(t *testing.T, result chan error) error {
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("no result")
+		return nil
+	}
+}
+func awaitData(t *testing.T, result chan []byte) []byte {
+	select {
+	case data := <-result:
+		return data
+	case <-time.After(time.Second):
+		t.Fatal("no data")
+		return nil
+	}
+}
+
+func TestSend_Successful(t *testing.T) {
+	// Arrange
+	connection, peer, _ := setup(t, role.Computer)
+	expected := frames("H|\\^&", "L|1|N")
+	// Act
+	result := sendAsync(connection, "H|\\^&", "L|1|N")
+	// Assert
+	expectByte(t, peer, 0x05)
+	write(t, peer, 0x06)
+	assert.Equal(t, expected[0], readFrame(t, peer))
+	write(t, peer, 0x06)
+	assert.Equal(t, expected[1], readFrame(t, peer))
+	write(t, peer, 0x06)
+	expectByte(t, peer, 0x04)
+	assert.Nil(t, awaitError(t, result))
+}
+
+func TestSend_RetransmissionAfterNak(t *testing.T) {
+	// Arrange
+	connection, peer, _ := setup(t, role.Computer)
+	expected := frames("H|\\^&")
+	// Act
+	result := sendAsync(connection, "H|\\^&")
+	// Assert
+	expectByte(t, peer, 0x05)
+	write(t, peer, 0x06)
+	assert.Equal(t, expected[0], readFrame(t, peer))
+	write(t, peer, 0x15)
+	assert.Equal(t, expected[0], readFrame(t, peer))
+	write(t, peer, 0x06)
+	expectByte(t, peer, 0x04)
+	assert.Nil(t, awaitError(t, result))
+}
+
+func TestSend_MaxRetransmissionsReached(t *testing.T) {
+	// Arrange
+	connection, peer, _ := setup(t, role.Computer)
+	// Act
+	result := sendAsync(connection, "H|\\^&")
+	// Assert
+	expectByte(t, peer, 0x05)
+	write(t, peer, 0x06)
+	for i := 0; i < 6; i++ {
+		readFrame(t, peer)
+		write(t, peer, 0x15)
+	}
+	expectByte(t, peer, 0x04)
+	assert.ErrorIs(t, awaitError(t, result), errmsg.ErrTransmissionMaxRetransmissionsReached)
+}
+
+func TestSend_ReplyTimeout(t *testing.T) {
+	// Arrange
+	connection, peer, clock := setup(t, role.Computer)
+	// Act
+	result := sendAsync(connection, "H|\\^&")
+	// Assert
+	expectByte(t, peer, 0x05)
+	clock.fire(t, 15*time.Second, 1)
+	expectByte(t, peer, 0x04)
+	assert.ErrorIs(t, awaitError(t, result), errmsg.ErrTransmissionTimeout)
+}
+
+func TestSend_ReceiverBusy(t *testing.T) {
+	// Arrange
+	connection, peer, clock := setup(t, role.Computer)
+	// Act
+	result := sendAsync(connection, "H|\\^&")
+	// Assert
+	expectByte(t, peer, 0x05)
+	write(t, peer, 0x15)
+	clock.fire(t, 10*time.Second, 1)
+	expectByte(t, peer, 0x05)
+	write(t, peer, 0x06)
+	readFrame(t, peer)
+	write(t, peer, 0x06)
+	expectByte(t, peer, 0x04)
+	assert.Nil(t, awaitError(t, result))
+}
+
+func TestSend_ContentionComputerYields(t *testing.T) {
+	// Arrange
+	connection, peer, _ := setup(t, role.Computer)
+	incoming := frames("H|\\^&", "L|1|N")
+	// Act
+	received := receiveAsync(connection)
+	result := sendAsync(connection, "H|\\^&")
+	// Assert
+	expectByte(t, peer, 0x05)
+	// The instrument sends its own enquiry at the same time and repeats it after its delay
+	write(t, peer, 0x05)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	for _, frame := range incoming {
+		write(t, peer, frame...)
+		expectByte(t, peer, 0x06)
+	}
+	write(t, peer, 0x04)
+	assert.Equal(t, "H|\\^&\rL|1|N\r", string(awaitData(t, received)))
+	// The computer retries its own transmission afterwards
+	expectByte(t, peer, 0x05)
+	write(t, peer, 0x06)
+	readFrame(t, peer)
+	write(t, peer, 0x06)
+	expectByte(t, peer, 0x04)
+	assert.Nil(t, awaitError(t, result))
+}
+
+func TestSend_ContentionInstrumentRetries(t *testing.T) {
+	// Arrange
+	connection, peer, clock := setup(t, role.Instrument)
+	// Act
+	result := sendAsync(connection, "H|\\^&")
+	// Assert
+	expectByte(t, peer, 0x05)
+	write(t, peer, 0x05)
+	clock.fire(t, time.Second, 1)
+	expectByte(t, peer, 0x05)
+	write(t, peer, 0x06)
+	readFrame(t, peer)
+	write(t, peer, 0x06)
+	expectByte(t, peer, 0x04)
+	assert.Nil(t, awaitError(t, result))
+}
+
+func TestReceive_Successful(t *testing.T) {
+	// Arrange
+	connection, peer, _ := setup(t, role.Computer)
+	incoming := frames("H|\\^&", "R|1|"+strings.Repeat("x", 300), "L|1|N")
+	// Act
+	received := receiveAsync(connection)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	for _, frame := range incoming {
+		write(t, peer, frame...)
+		expectByte(t, peer, 0x06)
+	}
+	write(t, peer, 0x04)
+	// Assert
+	assert.Equal(t, "H|\\^&\rR|1|"+strings.Repeat("x", 300)+"\rL|1|N\r", string(awaitData(t, received)))
+}
+
+func TestReceive_CorruptedFrameIsRejected(t *testing.T) {
+	// Arrange
+	connection, peer, _ := setup(t, role.Computer)
+	incoming := frames("H|\\^&")
+	corrupted := append([]byte{}, incoming[0]...)
+	corrupted[3] = 'X'
+	// Act
+	received := receiveAsync(connection)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	write(t, peer, corrupted...)
+	expectByte(t, peer, 0x15)
+	write(t, peer, incoming[0]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, 0x04)
+	// Assert
+	assert.Equal(t, "H|\\^&\r", string(awaitData(t, received)))
+}
+
+func TestReceive_RepeatedFrameIsAcceptedOnce(t *testing.T) {
+	// Arrange
+	connection, peer, _ := setup(t, role.Computer)
+	incoming := frames("H|\\^&", "L|1|N")
+	// Act
+	received := receiveAsync(connection)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	write(t, peer, incoming[0]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, incoming[0]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, incoming[1]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, 0x04)
+	// Assert
+	assert.Equal(t, "H|\\^&\rL|1|N\r", string(awaitData(t, received)))
+}
+
+func TestReceive_WrongFrameNumberIsRejected(t *testing.T) {
+	// Arrange
+	connection, peer, _ := setup(t, role.Computer)
+	incoming := frames("H|\\^&", "L|1|N")
+	// Act
+	received := receiveAsync(connection)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	write(t, peer, incoming[1]...)
+	expectByte(t, peer, 0x15)
+	write(t, peer, incoming[0]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, incoming[1]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, 0x04)
+	// Assert
+	assert.Equal(t, "H|\\^&\rL|1|N\r", string(awaitData(t, received)))
+}
+
+func TestReceive_TimeoutDiscardsPartialMessage(t *testing.T) {
+	// Arrange
+	connection, peer, clock := setup(t, role.Computer)
+	partial := frames("R|1|" + strings.Repeat("x", 300))
+	complete := frames("H|\\^&")
+	// Act
+	received := receiveAsync(connection)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	write(t, peer, partial[0]...)
+	expectByte(t, peer, 0x06)
+	clock.fire(t, 30*time.Second, 2)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	write(t, peer, complete[0]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, 0x04)
+	// Assert
+	assert.Equal(t, "H|\\^&\r", string(awaitData(t, received)))
+}
+
+func TestReceive_LineNoiseDoesNotExtendTimeout(t *testing.T) {
+	// Arrange
+	connection, peer, clock := setup(t, role.Computer)
+	partial := frames("R|1|" + strings.Repeat("x", 300))
+	complete := frames("H|\\^&")
+	// Act
+	received := receiveAsync(connection)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	write(t, peer, partial[0]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, 'n', 'o', 'i', 's', 'e')
+	clock.fire(t, 30*time.Second, 2)
+	write(t, peer, 0x05)
+	expectByte(t, peer, 0x06)
+	write(t, peer, complete[0]...)
+	expectByte(t, peer, 0x06)
+	write(t, peer, 0x04)
+	// Assert
+	assert.Equal(t, "H|\\^&\r", string(awaitData(t, received)))
+}
+
+func TestRun_ConnectionClosed(t *testing.T) {
+	// Arrange
+	local, peer := net.Pipe()
+	connection := NewConnection(local)
+	result := make(chan error, 1)
+	go func() {
+		result <- connection.Run(context.Background())
+	}()
+	// Act
+	peer.Close()
+	// Assert
+	assert.ErrorIs(t, awaitError(t, result), errmsg.ErrTransmissionConnectionClosed)
+	_, err := connection.Receive(context.Background())
+	assert.ErrorIs(t, err, errmsg.ErrTransmissionConnectionClosed)
+}
@@ -0,0 +1,27 @@
+package astm
+
+import (
+	"github.com/blutspende/go-astm/v3/models/astmmodels"
+)
+
+// RawFields is a record field type to preserve the data not mapped to any other field, marshal puts it back at the original positions
+type RawFields = astmmodels.RawFields
+
+// DateTime is a date/time field type that keeps the precision and the offset of the original value for marshal
+type DateTime = astmmodels.DateTime
+
+// Decimal is an exact decimal field type that keeps the digits and the scale of the original value for marshal
+type Decimal = astmmodels.Decimal
+
+// ParseDecimal parses a decimal in the form [+-]digits[.digits]
+func ParseDecimal(value string) (Decimal, error) {
+	return astmmodels.ParseDecimal(value)
+}
+
+// MeasurementValue is a result value field type separating qualifiers (<0.05, 1:160, 3+) from the numeric part
+type MeasurementValue = astmmodels.MeasurementValue
+
+// ParseMeasurementValue splits a result value (e.g. from a string field) into operator, numeric part and text
+func ParseMeasurementValue(value string) MeasurementValue {
+	return astmmodels.ParseMeasurementValue(value)
+}
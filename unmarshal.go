@@ -1,7 +1,9 @@
 package astm
 
 import (
+	"errors"
 	"github.com/blutspende/bloodlab-common/encoding"
+	"github.com/blutspende/go-astm/v3/errmsg"
 	"github.com/blutspende/go-astm/v3/functions"
 	"github.com/blutspende/go-astm/v3/models/astmmodels"
 )
@@ -12,6 +14,23 @@ func Unmarshal(messageData []byte, targetStruct interface{}, configuration ...as
 	if err != nil {
 		return err
 	}
+	return unmarshal(messageData, targetStruct, config, nil)
+}
+
+// UnmarshalWithReport works like Unmarshal and also reports the input data that was not mapped to the target structure
+func UnmarshalWithReport(messageData []byte, targetStruct interface{}, configuration ...astmmodels.Configuration) (report *astmmodels.UnmarshalReport, err error) {
+	// Load configuration
+	config, err := loadConfiguration(configuration...)
+	if err != nil {
+		return nil, err
+	}
+	// Collect the report while parsing
+	report = &astmmodels.UnmarshalReport{}
+	err = unmarshal(messageData, targetStruct, config, report)
+	return report, err
+}
+
+func unmarshal(messageData []byte, targetStruct interface{}, config *astmmodels.Configuration, report *astmmodels.UnmarshalReport) (err error) {
 	// Convert encoding to UTF8
 	utf8Data, err := encoding.ConvertFromEncodingToUtf8(messageData, config.Encoding)
 	if err != nil {
@@ -24,10 +43,23 @@ func Unmarshal(messageData []byte, targetStruct interface{}, configuration ...as
 	}
 	// Parse the lines into the target structure
 	lineIndex := 0
-	err = functions.ParseStruct(lines, targetStruct, &lineIndex, 1, 0, config)
-	if err != nil {
+	err = functions.ParseStructWithReport(lines, targetStruct, &lineIndex, 1, 0, config, report)
+	// Errors collected in lenient mode do not stop the parsing
+	var parseErrors errmsg.ParseErrors
+	if err != nil && !errors.As(err, &parseErrors) {
 		return err
 	}
-	// Return nil if everything went well
-	return nil
+	// Report the lines left over (if requested)
+	functions.ReportSkippedLines(lines, lineIndex, config, report)
+	// Return the collected errors or nil if everything went well
+	return err
 }
+
+// ParseError is returned by Unmarshal with the position of the problem, errors.Is still matches the wrapped error
+type ParseError = errmsg.ParseError
+
+// ParseErrors is returned by Unmarshal in lenient parsing mode with all the errors that did not stop the parsing
+type ParseErrors = errmsg.ParseErrors
+
+// AstmUnmarshaler is implemented by field types that parse themselves from an ASTM field value
+type AstmUnmarshaler = astmmodels.AstmUnmarshaler